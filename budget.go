@@ -0,0 +1,102 @@
+package synx
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// BudgetError is returned when the remaining time of the context is not enough.
+// Matches context.DeadlineExceeded with errors.Is.
+type BudgetError struct {
+	// Available time for the call.
+	Available time.Duration
+
+	// Floor is a minimal time required for the call.
+	Floor time.Duration
+}
+
+func (e *BudgetError) Error() string {
+	return fmt.Sprintf("synx: insufficient time budget: %v available, %v required", e.Available, e.Floor)
+}
+
+// Is reports whether target is context.DeadlineExceeded.
+func (e *BudgetError) Is(target error) bool {
+	return target == context.DeadlineExceeded
+}
+
+// Budget splits the remaining time of the context between sub-steps.
+type Budget struct {
+	ctx     context.Context
+	reserve time.Duration
+	floor   time.Duration
+}
+
+// NewBudget returns a new Budget for the ctx.
+// Reserve is kept from the context deadline (ex: to write a response).
+// Child contexts with less than floor time fail fast with *BudgetError.
+func NewBudget(ctx context.Context, reserve, floor time.Duration) *Budget {
+	switch {
+	case reserve < 0:
+		panic("synx: reserve cannot be negative")
+	case floor < 0:
+		panic("synx: floor cannot be negative")
+	}
+
+	return &Budget{
+		ctx:     ctx,
+		reserve: reserve,
+		floor:   floor,
+	}
+}
+
+// Remaining returns the time till the context deadline minus reserve.
+// The ok result is false if the context has no deadline.
+func (b *Budget) Remaining() (remaining time.Duration, ok bool) {
+	deadline, ok := b.ctx.Deadline()
+	if !ok {
+		return 0, false
+	}
+	return time.Until(deadline) - b.reserve, true
+}
+
+// Fraction returns a child context with a fraction of the remaining time.
+// Fraction must be in range (0, 1]. If the context has no deadline, the child has no deadline too.
+func (b *Budget) Fraction(fraction float64) (context.Context, context.CancelFunc, error) {
+	if fraction <= 0 || fraction > 1 {
+		panic("synx: fraction must be in range (0, 1]")
+	}
+
+	remaining, ok := b.Remaining()
+	if !ok {
+		ctx, cancel := context.WithCancel(b.ctx)
+		return ctx, cancel, nil
+	}
+	return b.withTimeout(time.Duration(float64(remaining) * fraction))
+}
+
+// Take returns a child context with timeout d but no more than the remaining time.
+func (b *Budget) Take(d time.Duration) (context.Context, context.CancelFunc, error) {
+	if remaining, ok := b.Remaining(); ok && remaining < d {
+		d = remaining
+	}
+	return b.withTimeout(d)
+}
+
+func (b *Budget) withTimeout(timeout time.Duration) (context.Context, context.CancelFunc, error) {
+	if timeout <= 0 || timeout < b.floor {
+		return nil, nil, &BudgetError{
+			Available: timeout,
+			Floor:     b.floor,
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(b.ctx, timeout)
+	return ctx, cancel, nil
+}
+
+// ContextWithBudget returns a child context with a fraction of the remaining time of ctx minus reserve.
+// Returns *BudgetError if there is no time left. See Budget.
+func ContextWithBudget(ctx context.Context, fraction float64, reserve time.Duration) (context.Context, context.CancelFunc, error) {
+	return NewBudget(ctx, reserve, 0).Fraction(fraction)
+}
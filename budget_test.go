@@ -0,0 +1,77 @@
+package synx
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestContextWithBudget(t *testing.T) {
+	parent, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	ctx, cancel, err := ContextWithBudget(parent, 0.5, 200*time.Millisecond)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer cancel()
+
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		t.Fatal("must have deadline")
+	}
+	if left := time.Until(deadline); left > 900*time.Millisecond || left < 800*time.Millisecond {
+		t.Fatalf("got %v, want about %v", left, 900*time.Millisecond)
+	}
+}
+
+func TestContextWithBudgetNoDeadline(t *testing.T) {
+	ctx, cancel, err := ContextWithBudget(context.Background(), 0.5, time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer cancel()
+
+	if _, ok := ctx.Deadline(); ok {
+		t.Fatal("must not have deadline")
+	}
+}
+
+func TestBudgetFloor(t *testing.T) {
+	parent, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	b := NewBudget(parent, 500*time.Millisecond, 300*time.Millisecond)
+
+	ctx, cancel, err := b.Take(100 * time.Second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer cancel()
+
+	if left := time.Until(deadlineOf(t, ctx)); left > 500*time.Millisecond {
+		t.Fatalf("got %v, want no more than %v", left, 500*time.Millisecond)
+	}
+
+	_, _, err = b.Fraction(0.5)
+
+	var berr *BudgetError
+	if !errors.As(err, &berr) {
+		t.Fatalf("got %v, want %T", err, berr)
+	}
+	if berr.Floor != 300*time.Millisecond {
+		t.Fatalf("got %v, want %v", berr.Floor, 300*time.Millisecond)
+	}
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("got %v, want %v", err, context.DeadlineExceeded)
+	}
+}
+
+func deadlineOf(t *testing.T, ctx context.Context) time.Time {
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		t.Fatal("must have deadline")
+	}
+	return deadline
+}
@@ -2,12 +2,132 @@ package synx
 
 import (
 	"context"
+	"sync"
 	"time"
 )
 
 // ContextFromSignal returns a context based on <-chan struct{}.
-func ContextFromSignal(c <-chan struct{}) context.Context {
-	return chanCtx(c)
+// The context is done when c is closed. See SignalOption to configure it.
+func ContextFromSignal(c <-chan struct{}, opts ...SignalOption) context.Context {
+	if len(opts) == 0 {
+		return chanCtx(c)
+	}
+	return newSignalCtx(c, opts)
+}
+
+// ContextFromSignalWithDeadline is like ContextFromSignal but the context is also done
+// with context.DeadlineExceeded when the deadline expires.
+//
+// Cancel releases resources (a goroutine and a timer) associated with the context,
+// so it must be called as soon as the operations running in this context complete.
+// The context is done with context.Canceled after cancel is called.
+func ContextFromSignalWithDeadline(c <-chan struct{}, deadline time.Time, opts ...SignalOption) (context.Context, context.CancelFunc) {
+	ctx := newSignalCtx(c, opts)
+	ctx.deadline, ctx.hasDeadline = deadline, true
+
+	done := make(chan struct{})
+	stop := make(chan struct{})
+	ctx.done = done
+
+	var once sync.Once
+	finish := func(err error) {
+		once.Do(func() {
+			if err != nil {
+				ctx.err = err
+			}
+			close(done)
+			close(stop)
+		})
+	}
+
+	timer := time.AfterFunc(time.Until(deadline), func() {
+		finish(context.DeadlineExceeded)
+	})
+
+	go func() {
+		select {
+		case <-c:
+			finish(nil)
+			timer.Stop()
+		case <-stop:
+		}
+	}()
+
+	return ctx, func() {
+		finish(context.Canceled)
+		timer.Stop()
+	}
+}
+
+func newSignalCtx(c <-chan struct{}, opts []SignalOption) *signalCtx {
+	ctx := &signalCtx{
+		done: c,
+		err:  context.Canceled,
+	}
+	for _, opt := range opts {
+		opt(ctx)
+	}
+	return ctx
+}
+
+// SignalOption configures a context returned by ContextFromSignal.
+type SignalOption func(*signalCtx)
+
+// SignalParent sets parent context for values. Cancellation of the parent is not propagated.
+func SignalParent(parent context.Context) SignalOption {
+	return func(c *signalCtx) { c.parent = parent }
+}
+
+// SignalErr sets the error returned by Err when the signal is closed.
+// Default (and used when err is nil) is context.Canceled.
+func SignalErr(err error) SignalOption {
+	return func(c *signalCtx) {
+		if err == nil {
+			err = context.Canceled
+		}
+		c.err = err
+	}
+}
+
+// SignalFromContext returns a channel which is closed when ctx is done.
+// No goroutine is started. Unlike ctx.Done the returned channel is never nil,
+// for contexts which cannot be cancelled it is a channel which is never closed.
+func SignalFromContext(ctx context.Context) <-chan struct{} {
+	if done := ctx.Done(); done != nil {
+		return done
+	}
+	return neverSignal
+}
+
+// neverSignal is never closed.
+var neverSignal = make(chan struct{})
+
+type signalCtx struct {
+	done        <-chan struct{}
+	parent      context.Context
+	err         error // written before done is closed
+	deadline    time.Time
+	hasDeadline bool
+}
+
+func (c *signalCtx) Done() <-chan struct{} { return c.done }
+
+func (c *signalCtx) Deadline() (time.Time, bool) { return c.deadline, c.hasDeadline }
+
+func (c *signalCtx) Err() error {
+	select {
+	case <-c.done:
+		return c.err
+	default:
+		return nil
+	}
+}
+
+func (c *signalCtx) Value(key any) any {
+	if c.parent == nil {
+		return nil
+	}
+	return c.parent.Value(key)
 }
 
 type chanCtx <-chan struct{}
@@ -55,6 +175,7 @@ func GetValue[T any](ctx context.Context) T {
 }
 
 // ContextWithoutValues returns context without any value set. However new values can be added.
+// See ContextWithoutCancel to keep values but drop cancellation and deadline.
 func ContextWithoutValues(ctx context.Context) context.Context {
 	return &contextWithoutValues{ctx}
 }
@@ -63,6 +184,48 @@ type contextWithoutValues struct{ context.Context }
 
 func (c *contextWithoutValues) Value(_ any) any { return nil }
 
+// ContextWithOnlyValues returns context with only values for the given keys.
+// However new values can be added.
+func ContextWithOnlyValues(ctx context.Context, keys ...any) context.Context {
+	return newFilterValuesCtx(ctx, true, keys)
+}
+
+// ContextWithoutKeys returns context without values for the given keys.
+// However new values can be added.
+func ContextWithoutKeys(ctx context.Context, keys ...any) context.Context {
+	return newFilterValuesCtx(ctx, false, keys)
+}
+
+func newFilterValuesCtx(ctx context.Context, only bool, keys []any) *filterValuesCtx {
+	c := &filterValuesCtx{
+		Context: ctx,
+		only:    only,
+		keys:    make(map[any]struct{}, len(keys)),
+	}
+	for _, key := range keys {
+		c.keys[key] = struct{}{}
+	}
+	return c
+}
+
+type filterValuesCtx struct {
+	context.Context
+	only bool
+	keys map[any]struct{}
+}
+
+func (c *filterValuesCtx) Value(key any) any {
+	if !c.visible(key) {
+		return nil
+	}
+	return c.Context.Value(key)
+}
+
+func (c *filterValuesCtx) visible(key any) bool {
+	_, ok := c.keys[key]
+	return ok == c.only
+}
+
 // ContextWithValues creates a new context based on ctx and map of values.
 // Shorter version of context.WithValue in a loop.
 func ContextWithValues(ctx context.Context, values map[any]any) context.Context {
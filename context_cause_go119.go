@@ -0,0 +1,19 @@
+//go:build !go1.20
+
+package synx
+
+import "context"
+
+// ContextWithCancelCause behaves like context.WithCancel but returns a CancelCauseFunc.
+// See context.WithCancelCause on Go 1.20+.
+func ContextWithCancelCause(parent context.Context) (ctx context.Context, cancel CancelCauseFunc) {
+	return withCancelCause(parent)
+}
+
+// ContextCause returns a non-nil error explaining why ctx was canceled.
+// Only contexts created by ContextWithCancelCause report a cause,
+// for other contexts ctx.Err() is returned.
+// See context.Cause on Go 1.20+.
+func ContextCause(ctx context.Context) error {
+	return cause(ctx)
+}
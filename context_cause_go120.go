@@ -0,0 +1,18 @@
+//go:build go1.20
+
+package synx
+
+import "context"
+
+// ContextWithCancelCause behaves like context.WithCancel but returns a CancelCauseFunc.
+// See context.WithCancelCause.
+func ContextWithCancelCause(parent context.Context) (ctx context.Context, cancel CancelCauseFunc) {
+	ctx, cancelCause := context.WithCancelCause(parent)
+	return ctx, CancelCauseFunc(cancelCause)
+}
+
+// ContextCause returns a non-nil error explaining why ctx was canceled.
+// See context.Cause.
+func ContextCause(ctx context.Context) error {
+	return context.Cause(ctx)
+}
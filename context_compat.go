@@ -0,0 +1,125 @@
+package synx
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// CancelCauseFunc behaves like context.CancelFunc but additionally sets the cancellation cause.
+// See context.CancelCauseFunc on Go 1.20+.
+type CancelCauseFunc func(cause error)
+
+// Backports of the context functions which are not present in Go 1.18.
+// Exported functions use stdlib versions if they are available.
+
+func withCancelCause(parent context.Context) (context.Context, CancelCauseFunc) {
+	ctx, cancel := context.WithCancel(parent)
+	c := &cancelCauseCtx{
+		Context: ctx,
+		parent:  parent,
+	}
+
+	return c, func(cause error) {
+		c.mu.Lock()
+		if !c.set && ctx.Err() == nil {
+			if cause == nil {
+				cause = context.Canceled
+			}
+			c.cause, c.set = cause, true
+		}
+		c.mu.Unlock()
+		cancel()
+	}
+}
+
+// used as a context key by cancelCauseCtx.
+var cancelCauseKey int
+
+type cancelCauseCtx struct {
+	context.Context
+	parent context.Context
+
+	mu    sync.Mutex
+	set   bool
+	cause error
+}
+
+func (c *cancelCauseCtx) Value(key any) any {
+	if key == &cancelCauseKey {
+		return c
+	}
+	return c.Context.Value(key)
+}
+
+func cause(ctx context.Context) error {
+	if ctx.Err() == nil {
+		return nil
+	}
+
+	c, ok := ctx.Value(&cancelCauseKey).(*cancelCauseCtx)
+	if !ok {
+		return ctx.Err()
+	}
+
+	c.mu.Lock()
+	set, err := c.set, c.cause
+	c.mu.Unlock()
+
+	switch {
+	case set:
+		return err
+	case c.parent.Err() != nil:
+		return cause(c.parent)
+	default:
+		return ctx.Err()
+	}
+}
+
+func withoutCancel(parent context.Context) context.Context {
+	if parent == nil {
+		panic("synx: cannot create context from nil parent")
+	}
+	return contextWithoutCancel{parent}
+}
+
+type contextWithoutCancel struct {
+	c context.Context
+}
+
+func (contextWithoutCancel) Deadline() (deadline time.Time, ok bool) { return }
+func (contextWithoutCancel) Done() <-chan struct{}                   { return nil }
+func (contextWithoutCancel) Err() error                              { return nil }
+func (c contextWithoutCancel) Value(key any) any                     { return c.c.Value(key) }
+
+func afterFunc(ctx context.Context, f func()) (stop func() bool) {
+	var once sync.Once
+	stopCh := make(chan struct{})
+
+	stop = func() bool {
+		stopped := false
+		once.Do(func() {
+			stopped = true
+			close(stopCh)
+		})
+		return stopped
+	}
+
+	done := ctx.Done()
+	if done == nil {
+		return stop
+	}
+
+	go func() {
+		select {
+		case <-done:
+			run := false
+			once.Do(func() { run = true })
+			if run {
+				f()
+			}
+		case <-stopCh:
+		}
+	}()
+	return stop
+}
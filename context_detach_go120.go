@@ -0,0 +1,19 @@
+//go:build !go1.21
+
+package synx
+
+import "context"
+
+// ContextWithoutCancel returns a copy of parent that is not canceled when parent is canceled.
+// See context.WithoutCancel on Go 1.21+.
+func ContextWithoutCancel(parent context.Context) context.Context {
+	return withoutCancel(parent)
+}
+
+// ContextAfterFunc arranges to call f in its own goroutine after ctx is done.
+// Calling stop stops the association of ctx with f,
+// it returns true if the call stopped f from being run.
+// See context.AfterFunc on Go 1.21+.
+func ContextAfterFunc(ctx context.Context, f func()) (stop func() bool) {
+	return afterFunc(ctx, f)
+}
@@ -0,0 +1,17 @@
+//go:build go1.21
+
+package synx
+
+import "context"
+
+// ContextWithoutCancel returns a copy of parent that is not canceled when parent is canceled.
+// See context.WithoutCancel.
+func ContextWithoutCancel(parent context.Context) context.Context {
+	return context.WithoutCancel(parent)
+}
+
+// ContextAfterFunc arranges to call f in its own goroutine after ctx is done.
+// See context.AfterFunc.
+func ContextAfterFunc(ctx context.Context, f func()) (stop func() bool) {
+	return context.AfterFunc(ctx, f)
+}
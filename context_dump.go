@@ -3,35 +3,256 @@ package synx
 import (
 	"context"
 	"fmt"
+	"sort"
+	"strings"
+	"time"
 	"unsafe"
 )
 
-// DumpContext values. Works for stdlib contexts only.
+// DumpContext values. Works for stdlib and synx contexts only.
+// If a key is set more than once, the value visible via ctx.Value is returned.
+// See InspectContext to get all layers of the context.
 func DumpContext(ctx context.Context) map[any]any {
 	if ctx == nil {
 		return nil
 	}
 
 	values := map[any]any{}
+	for _, layer := range InspectContext(ctx) {
+		if layer.HasValue && !layer.Shadowed {
+			values[layer.Key] = layer.Value
+		}
+	}
+	return values
+}
+
+// ContextLayer is a single layer of the context chain. See InspectContext.
+type ContextLayer struct {
+	// Kind of the layer: value, values, cancel, timer, merge, parent, etc.
+	// Type name is used for unknown contexts.
+	Kind string
+
+	// Depth of the layer in the tree. Each parent of a merged context is
+	// a "parent" layer one level deeper, followed by its layers two levels deeper.
+	Depth int
+
+	// Parent is an index of the merged context parent, set for "parent" layers only.
+	Parent int
+
+	// HasValue reports whether the layer holds a key and a value.
+	HasValue   bool
+	Key, Value any
+
+	// Shadowed reports whether the value is not visible via ctx.Value,
+	// because the key is set by a previous layer or values are removed.
+	Shadowed bool
+
+	// Deadline of the layer (if any).
+	HasDeadline bool
+	Deadline    time.Time
+
+	// Err of the layer. Non-nil if the layer is done.
+	Err error
+}
+
+// ContextChain is a list of context layers from the outermost to the innermost.
+type ContextChain []ContextLayer
+
+// String renders the chain as a tree, a layer per line.
+func (c ContextChain) String() string {
+	var sb strings.Builder
+	for _, layer := range c {
+		sb.WriteString(strings.Repeat("  ", layer.Depth))
+		sb.WriteString("- ")
+		sb.WriteString(layer.Kind)
+		if layer.Kind == "parent" {
+			fmt.Fprintf(&sb, " %d", layer.Parent)
+		}
+
+		if layer.HasValue {
+			fmt.Fprintf(&sb, " %v=%v", layer.Key, layer.Value)
+			if layer.Shadowed {
+				sb.WriteString(" (shadowed)")
+			}
+		}
+		if layer.HasDeadline {
+			fmt.Fprintf(&sb, " deadline=%s", layer.Deadline.Format(time.RFC3339Nano))
+		}
+		if layer.Err != nil {
+			fmt.Fprintf(&sb, " done=%q", layer.Err)
+		}
+		sb.WriteByte('\n')
+	}
+	return sb.String()
+}
+
+// InspectContext returns all layers of the context. Works for stdlib and synx contexts only,
+// inspection stops at the first unknown context.
+func InspectContext(ctx context.Context) ContextChain {
+	if ctx == nil {
+		return nil
+	}
+
+	var chain ContextChain
+	visible := func(any) bool { return true }
+	inspectContext(ctx, 0, visible, map[any]struct{}{}, &chain)
+	return chain
+}
+
+func inspectContext(ctx context.Context, depth int, visible func(key any) bool, seen map[any]struct{}, chain *ContextChain) {
 	for {
+		layer := ContextLayer{
+			Depth: depth,
+			Err:   ctx.Err(),
+		}
+		layer.Deadline, layer.HasDeadline = ctx.Deadline()
+
+		addValue := func(layer ContextLayer, key, value any) {
+			_, ok := seen[key]
+			if visible(key) {
+				seen[key] = struct{}{}
+			}
+
+			layer.HasValue, layer.Key, layer.Value = true, key, value
+			layer.Shadowed = ok || !visible(key)
+			*chain = append(*chain, layer)
+		}
+
+		switch c := ctx.(type) {
+		case *multiValuesCtx:
+			layer.Kind = "values"
+			for _, key := range sortedKeys(c.values) {
+				addValue(layer, key, c.values[key])
+			}
+			ctx = c.Context
+			continue
+
+		case *contextWithoutValues:
+			layer.Kind = "withoutValues"
+			*chain = append(*chain, layer)
+			visible = func(any) bool { return false }
+			ctx = c.Context
+			continue
+
+		case *filterValuesCtx:
+			layer.Kind = "withoutKeys"
+			if c.only {
+				layer.Kind = "onlyValues"
+			}
+			*chain = append(*chain, layer)
+			parentVisible := visible
+			visible = func(key any) bool { return c.visible(key) && parentVisible(key) }
+			ctx = c.Context
+			continue
+
+		case *mergedContext:
+			layer.Kind = "merge"
+			*chain = append(*chain, layer)
+			inspectParent(c.ctx1, 0, depth+1, visible, seen, chain)
+			inspectParent(c.ctx2, 1, depth+1, visible, seen, chain)
+			return
+
+		case *mergedNContext:
+			layer.Kind = "merge"
+			*chain = append(*chain, layer)
+			for i, c := range c.ctxs {
+				inspectParent(c, i, depth+1, visible, seen, chain)
+			}
+			return
+
+		case *cancelCauseCtx:
+			ctx = c.Context // wraps cancelCtx which is inspected below
+			continue
+
+		case contextWithoutCancel:
+			layer.Kind = "withoutCancel"
+			*chain = append(*chain, layer)
+			ctx = c.c
+			continue
+
+		case chanCtx:
+			layer.Kind = "signal"
+			*chain = append(*chain, layer)
+			return
+
+		case *signalCtx:
+			layer.Kind = "signal"
+			*chain = append(*chain, layer)
+			if c.parent == nil {
+				return
+			}
+			ctx = c.parent
+			continue
+		}
+
 		// cannot use type-switch here because those types are unexported
-		switch fmt.Sprintf("%T", ctx) {
+		switch typ := fmt.Sprintf("%T", ctx); typ {
 		case "*context.valueCtx":
 			v := *(*valueCtx)((*iface)(unsafe.Pointer(&ctx)).data)
-			values[v.key] = v.value
+			layer.Kind = "value"
+			addValue(layer, v.key, v.value)
 			ctx = v.Context
 
-		case "*context.timerCtx", "*context.cancelCtx":
-			v := *(*parentCtx)((*iface)(unsafe.Pointer(&ctx)).data)
-			ctx = v.Context
+		case "*context.cancelCtx", "*context.timerCtx",
+			"*context.afterFuncCtx", "*context.stopCtx",
+			"context.withoutCancelCtx":
+			layer.Kind = stdContextKinds[typ]
+			*chain = append(*chain, layer)
+			// all of them have parent context as a first field
+			ctx = *(*context.Context)((*iface)(unsafe.Pointer(&ctx)).data)
+
+		case "context.backgroundCtx", "context.todoCtx", "*context.emptyCtx":
+			layer.Kind = "todo"
+			if ctx == context.Background() {
+				layer.Kind = "background"
+			}
+			*chain = append(*chain, layer)
+			return
 
 		default:
-			// know nothing about other types (or nil), so returning
-			return values
+			// know nothing about other types, so returning
+			layer.Kind = typ
+			*chain = append(*chain, layer)
+			return
 		}
 	}
 }
 
+// inspectParent adds a "parent" layer for idx-th parent of a merged context
+// and the parent's layers beneath it.
+func inspectParent(ctx context.Context, idx, depth int, visible func(key any) bool, seen map[any]struct{}, chain *ContextChain) {
+	layer := ContextLayer{
+		Kind:   "parent",
+		Depth:  depth,
+		Parent: idx,
+		Err:    ctx.Err(),
+	}
+	layer.Deadline, layer.HasDeadline = ctx.Deadline()
+	*chain = append(*chain, layer)
+
+	inspectContext(ctx, depth+1, visible, seen, chain)
+}
+
+var stdContextKinds = map[string]string{
+	"*context.cancelCtx":       "cancel",
+	"*context.timerCtx":        "timer",
+	"*context.afterFuncCtx":    "afterFunc",
+	"*context.stopCtx":         "stop",
+	"context.withoutCancelCtx": "withoutCancel",
+}
+
+// sortedKeys returns keys of the map sorted by their string representation.
+func sortedKeys(m map[any]any) []any {
+	keys := make([]any, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return fmt.Sprint(keys[i]) < fmt.Sprint(keys[j])
+	})
+	return keys
+}
+
 // Same as runtime.(iface)
 type iface struct {
 	_    unsafe.Pointer
@@ -43,9 +264,3 @@ type valueCtx struct {
 	context.Context
 	key, value any
 }
-
-// Same as context.(*timerCtx) and context.(*cancelCtx)
-type parentCtx struct {
-	context.Context
-	_ struct{}
-}
@@ -2,14 +2,13 @@ package synx
 
 import (
 	"context"
+	"fmt"
 	"reflect"
 	"testing"
 	"time"
 )
 
 func TestDumpContext(t *testing.T) {
-	t.Skip()
-
 	var cancel context.CancelFunc
 
 	withCancel := func(ctx context.Context) context.Context {
@@ -30,6 +29,18 @@ func TestDumpContext(t *testing.T) {
 		return ctx
 	}
 
+	withCancelCause := func(ctx context.Context) context.Context {
+		ctx, cancel := ContextWithCancelCause(ctx)
+		defer cancel(nil)
+		return ctx
+	}
+
+	withMergeN := func(ctxs ...context.Context) context.Context {
+		ctx, stop := ContextMergeN(ctxs...)
+		defer stop()
+		return ctx
+	}
+
 	withNop := func(ctx context.Context) context.Context {
 		type nopCtx struct {
 			context.Context
@@ -109,6 +120,92 @@ func TestDumpContext(t *testing.T) {
 				"foo3": "bar3",
 			},
 		},
+		{
+			ctx: ContextWithValues(context.WithValue(context.Background(), "foo", "bar"), map[any]any{
+				"foo":  "shadowed",
+				"foo2": "bar2",
+			}),
+			wantValues: map[any]any{
+				"foo":  "shadowed",
+				"foo2": "bar2",
+			},
+		},
+		{
+			ctx: context.WithValue(ContextWithoutValues(
+				context.WithValue(context.Background(), "foo", "bar"),
+			), "foo2", "bar2"),
+			wantValues: map[any]any{
+				"foo2": "bar2",
+			},
+		},
+		{
+			ctx: ContextWithoutKeys(ContextWithOnlyValues(
+				context.WithValue(context.WithValue(
+					context.WithValue(context.Background(), "foo", "bar"),
+					"foo2", "bar2"),
+					"foo3", "bar3"),
+				"foo", "foo2"),
+				"foo2",
+			),
+			wantValues: map[any]any{
+				"foo": "bar",
+			},
+		},
+		{
+			ctx: ContextMerge(
+				ContextWithoutKeys(context.WithValue(context.Background(), "foo", "hidden"), "foo"),
+				context.WithValue(context.Background(), "foo", "bar"),
+			),
+			wantValues: map[any]any{
+				"foo": "bar",
+			},
+		},
+		{
+			ctx: ContextMerge(
+				ContextWithoutValues(context.WithValue(context.Background(), "foo", "hidden")),
+				context.WithValue(context.Background(), "foo", "bar"),
+			),
+			wantValues: map[any]any{
+				"foo": "bar",
+			},
+		},
+		{
+			ctx: ContextMerge(
+				context.WithValue(context.Background(), "foo", "bar"),
+				withCancel(context.WithValue(context.Background(), "foo", "bar1")),
+			),
+			wantValues: map[any]any{
+				"foo": "bar",
+			},
+		},
+		{
+			ctx: withMergeN(
+				context.WithValue(context.Background(), "foo", "bar"),
+				context.WithValue(context.Background(), "foo2", "bar2"),
+				withTimeout(context.WithValue(context.Background(), "foo3", "bar3")),
+			),
+			wantValues: map[any]any{
+				"foo":  "bar",
+				"foo2": "bar2",
+				"foo3": "bar3",
+			},
+		},
+		{
+			ctx: ContextWithoutCancel(withCancelCause(
+				context.WithValue(context.Background(), "foo", "bar"),
+			)),
+			wantValues: map[any]any{
+				"foo": "bar",
+			},
+		},
+		{
+			ctx: withoutCancel(context.WithValue(
+				ContextFromSignal(make(chan struct{})), "foo", "bar"),
+			),
+			wantValues: map[any]any{
+				"foo": "bar",
+			},
+		},
 	}
 
 	for i, test := range testCases {
@@ -118,3 +215,50 @@ func TestDumpContext(t *testing.T) {
 		}
 	}
 }
+
+func TestInspectContext(t *testing.T) {
+	if chain := InspectContext(nil); chain != nil {
+		t.Fatalf("got %v, want %v", chain, nil)
+	}
+
+	deadline := time.Now().Add(time.Hour)
+	ctx, cancel := context.WithDeadline(context.WithValue(context.Background(), "foo", "bar"), deadline)
+	defer cancel()
+
+	ctx1, cancel1 := context.WithCancel(context.Background())
+	cancel1()
+
+	ctx = ContextMerge(context.WithValue(ctx, "foo", "baz"), ctx1)
+
+	chain := InspectContext(ctx)
+
+	want := ContextChain{
+		{Kind: "merge", HasDeadline: true, Deadline: deadline, Err: context.Canceled},
+		{Kind: "parent", Depth: 1, Parent: 0, HasDeadline: true, Deadline: deadline},
+		{Kind: "value", Depth: 2, HasValue: true, Key: "foo", Value: "baz", HasDeadline: true, Deadline: deadline},
+		{Kind: "timer", Depth: 2, HasDeadline: true, Deadline: deadline},
+		{Kind: "value", Depth: 2, HasValue: true, Key: "foo", Value: "bar", Shadowed: true},
+		{Kind: "background", Depth: 2},
+		{Kind: "parent", Depth: 1, Parent: 1, Err: context.Canceled},
+		{Kind: "cancel", Depth: 2, Err: context.Canceled},
+		{Kind: "background", Depth: 2},
+	}
+	if !reflect.DeepEqual(chain, want) {
+		t.Fatalf("want %+v, got %+v", want, chain)
+	}
+
+	wantStr := fmt.Sprintf(`- merge deadline=%[1]s done="context canceled"
+  - parent 0 deadline=%[1]s
+    - value foo=baz deadline=%[1]s
+    - timer deadline=%[1]s
+    - value foo=bar (shadowed)
+    - background
+  - parent 1 done="context canceled"
+    - cancel done="context canceled"
+    - background
+`, deadline.Format(time.RFC3339Nano))
+
+	if got := chain.String(); got != wantStr {
+		t.Fatalf("want %s, got %s", wantStr, got)
+	}
+}
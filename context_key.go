@@ -0,0 +1,65 @@
+package synx
+
+import (
+	"context"
+	"fmt"
+)
+
+// Key is a typed context key. Different keys never collide even for the same T.
+// Use NewKey to create a key.
+type Key[T any] struct {
+	name       string
+	defaultVal T
+	hasDefault bool
+}
+
+// KeyOption configures a Key. See NewKey.
+type KeyOption[T any] func(*Key[T])
+
+// KeyDefault sets the default value returned when the key is absent.
+func KeyDefault[T any](v T) KeyOption[T] {
+	return func(k *Key[T]) {
+		k.defaultVal = v
+		k.hasDefault = true
+	}
+}
+
+// NewKey returns a new Key with the given name.
+// The name is used by String, so it is shown by DumpContext and InspectContext.
+func NewKey[T any](name string, opts ...KeyOption[T]) *Key[T] {
+	k := &Key[T]{name: name}
+	for _, opt := range opts {
+		opt(k)
+	}
+	return k
+}
+
+// Name of the key.
+func (k *Key[T]) Name() string { return k.name }
+
+// String implements fmt.Stringer.
+func (k *Key[T]) String() string { return k.name }
+
+// With attaches v to the context.
+func (k *Key[T]) With(ctx context.Context, v T) context.Context {
+	return context.WithValue(ctx, k, v)
+}
+
+// Get returns the value attached to the context.
+// If there is no value, the default value (or zero value) and false are returned.
+func (k *Key[T]) Get(ctx context.Context) (T, bool) {
+	if v, ok := ctx.Value(k).(T); ok {
+		return v, true
+	}
+	return k.defaultVal, false
+}
+
+// MustGet returns the value attached to the context or the default value.
+// Panics if there is no value and no default value is set.
+func (k *Key[T]) MustGet(ctx context.Context) T {
+	v, ok := k.Get(ctx)
+	if !ok && !k.hasDefault {
+		panic(fmt.Sprintf("synx: no value for key %q", k.name))
+	}
+	return v
+}
@@ -0,0 +1,60 @@
+package synx
+
+import (
+	"context"
+	"testing"
+)
+
+func TestKey(t *testing.T) {
+	requestID := NewKey[string]("request-id")
+	tenantID := NewKey[string]("tenant-id")
+
+	ctx := requestID.With(context.Background(), "req-1")
+	ctx = tenantID.With(ctx, "")
+
+	if got, ok := requestID.Get(ctx); !ok || got != "req-1" {
+		t.Fatalf("got (%v, %v), want (%v, %v)", got, ok, "req-1", true)
+	}
+	if got, ok := tenantID.Get(ctx); !ok || got != "" {
+		t.Fatalf("got (%v, %v), want (%v, %v)", got, ok, "", true)
+	}
+	if got, ok := requestID.Get(context.Background()); ok || got != "" {
+		t.Fatalf("got (%v, %v), want (%v, %v)", got, ok, "", false)
+	}
+
+	values := DumpContext(ctx)
+	for key, want := range map[any]any{requestID: "req-1", tenantID: ""} {
+		if got := values[key]; got != want {
+			t.Fatalf("got %v, want %v", got, want)
+		}
+	}
+
+	want := "- value tenant-id=\n- value request-id=req-1\n- background\n"
+	if got := InspectContext(ctx).String(); got != want {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+}
+
+func TestKeyDefault(t *testing.T) {
+	limit := NewKey("limit", KeyDefault(100))
+
+	if got, ok := limit.Get(context.Background()); ok || got != 100 {
+		t.Fatalf("got (%v, %v), want (%v, %v)", got, ok, 100, false)
+	}
+	if got := limit.MustGet(context.Background()); got != 100 {
+		t.Fatalf("got %v, want %v", got, 100)
+	}
+	if got := limit.MustGet(limit.With(context.Background(), 0)); got != 0 {
+		t.Fatalf("got %v, want %v", got, 0)
+	}
+}
+
+func TestKeyMustGet(t *testing.T) {
+	defer func() {
+		if r := recover(); r == nil {
+			t.Fatal("must panic")
+		}
+	}()
+
+	NewKey[int]("count").MustGet(context.Background())
+}
@@ -2,12 +2,19 @@ package synx
 
 import (
 	"context"
+	"reflect"
 	"sync"
 	"time"
 )
 
 // ContextMerge two conext into one, with values from both contexts, with a earliest deadline.
-// ctx1 is preferred for Value and Err.
+// ctx1 is preferred for Value. Err and ContextMergeCause report the context
+// which was done first (ctx1 if both were done before the merged context noticed it).
+//
+// If both contexts can be cancelled, Done is watched with ContextAfterFunc,
+// so on Go 1.21+ no goroutine is started for stdlib contexts. Before Go 1.21
+// (or for custom contexts) Done starts goroutines which live till one
+// of the contexts is cancelled, use ContextMergeN to be able to stop them.
 //
 // See: https://github.com/golang/go/issues/36503
 func ContextMerge(ctx1, ctx2 context.Context) context.Context {
@@ -33,8 +40,20 @@ type mergedContext struct {
 	doneOnce sync.Once
 	done     <-chan struct{}
 
-	errOnce sync.Once
-	err     error
+	mu  sync.Mutex
+	err error
+	idx int // index of the context which caused cancellation
+}
+
+// latch records err of idx-th context if no error is recorded yet.
+func (ctx *mergedContext) latch(idx int, err error) error {
+	ctx.mu.Lock()
+	defer ctx.mu.Unlock()
+
+	if ctx.err == nil {
+		ctx.err, ctx.idx = err, idx
+	}
+	return ctx.err
 }
 
 // Deadline implements context.Context
@@ -62,32 +81,49 @@ func (ctx *mergedContext) Done() <-chan struct{} {
 	ctx.doneOnce.Do(func() {
 		done := make(chan struct{})
 		ctx.done = done
-		go func() {
-			select {
-			case <-ctx.done1:
-			case <-ctx.done2:
+
+		var mu sync.Mutex
+		var stop1, stop2 func() bool
+		closed := false
+		finish := func(idx int, parent context.Context) {
+			ctx.latch(idx, parent.Err())
+
+			mu.Lock()
+			defer mu.Unlock()
+
+			if closed {
+				return
 			}
+			closed = true
+			stop1()
+			stop2()
 			close(done)
-		}()
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+		stop1 = ContextAfterFunc(ctx.ctx1, func() { finish(0, ctx.ctx1) })
+		stop2 = ContextAfterFunc(ctx.ctx2, func() { finish(1, ctx.ctx2) })
 	})
 	return ctx.done
 }
 
 // Err implements context.Context
 func (ctx *mergedContext) Err() error {
-	err1 := ctx.ctx1.Err()
-	err2 := ctx.ctx2.Err()
-	if err1 == nil && err2 == nil {
-		return nil
-	}
-	ctx.errOnce.Do(func() {
-		if err1 != nil {
-			ctx.err = err1
-		} else {
-			ctx.err = err2
-		}
-	})
-	return ctx.err
+	ctx.mu.Lock()
+	err := ctx.err
+	ctx.mu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	if err := ctx.ctx1.Err(); err != nil {
+		return ctx.latch(0, err)
+	}
+	if err := ctx.ctx2.Err(); err != nil {
+		return ctx.latch(1, err)
+	}
+	return nil
 }
 
 // Value implements context.Context
@@ -97,3 +133,177 @@ func (ctx *mergedContext) Value(key any) any {
 	}
 	return ctx.ctx2.Value(key)
 }
+
+// ContextMergeN merges contexts into one, with values from all contexts, with a earliest deadline.
+// Earlier contexts are preferred for Value.
+// The merged context is done when any of the contexts is done or stop is called.
+//
+// Stop releases resources (the watcher goroutine) associated with the merged context,
+// so it must be called as soon as the operations running in this context complete.
+//
+// Use ContextMergeCause to get a context which has triggered cancellation.
+func ContextMergeN(ctxs ...context.Context) (ctx context.Context, stop context.CancelFunc) {
+	if len(ctxs) == 0 {
+		panic("synx: at least one context must be passed")
+	}
+
+	m := &mergedNContext{
+		ctxs: ctxs,
+		done: make(chan struct{}),
+		stop: make(chan struct{}),
+		idx:  -1,
+	}
+
+	cases := make([]reflect.SelectCase, 0, len(ctxs)+1)
+	idxs := make([]int, 0, len(ctxs))
+	for i, ctx := range ctxs {
+		if err := ctx.Err(); err != nil {
+			m.finish(i, err)
+			return m, m.cancel
+		}
+
+		if done := ctx.Done(); done != nil {
+			cases = append(cases, reflect.SelectCase{
+				Dir:  reflect.SelectRecv,
+				Chan: reflect.ValueOf(done),
+			})
+			idxs = append(idxs, i)
+		}
+	}
+
+	if len(cases) > 0 {
+		cases = append(cases, reflect.SelectCase{
+			Dir:  reflect.SelectRecv,
+			Chan: reflect.ValueOf(m.stop),
+		})
+
+		go func() {
+			chosen, _, _ := reflect.Select(cases)
+			if chosen < len(idxs) {
+				i := idxs[chosen]
+				m.finish(i, ctxs[i].Err())
+			}
+		}()
+	}
+	return m, m.cancel
+}
+
+type mergedNContext struct {
+	ctxs []context.Context
+	done chan struct{}
+
+	stopOnce sync.Once
+	stop     chan struct{}
+
+	mu  sync.Mutex
+	err error
+	idx int
+}
+
+func (ctx *mergedNContext) finish(idx int, err error) {
+	ctx.mu.Lock()
+	defer ctx.mu.Unlock()
+
+	if ctx.err != nil {
+		return
+	}
+	ctx.err = err
+	ctx.idx = idx
+	close(ctx.done)
+}
+
+// poll finishes ctx if any of the contexts is done
+// but the watcher goroutine has not noticed it yet.
+func (ctx *mergedNContext) poll() {
+	for i, c := range ctx.ctxs {
+		if err := c.Err(); err != nil {
+			ctx.finish(i, err)
+			return
+		}
+	}
+}
+
+func (ctx *mergedNContext) cancel() {
+	ctx.poll()
+	ctx.finish(-1, context.Canceled)
+	ctx.stopOnce.Do(func() {
+		close(ctx.stop)
+	})
+}
+
+// Deadline implements context.Context
+func (ctx *mergedNContext) Deadline() (deadline time.Time, ok bool) {
+	for _, c := range ctx.ctxs {
+		if d, ok2 := c.Deadline(); ok2 && (!ok || d.Before(deadline)) {
+			deadline, ok = d, true
+		}
+	}
+	return deadline, ok
+}
+
+// Done implements context.Context
+func (ctx *mergedNContext) Done() <-chan struct{} {
+	return ctx.done
+}
+
+// Err implements context.Context
+func (ctx *mergedNContext) Err() error {
+	ctx.mu.Lock()
+	err := ctx.err
+	ctx.mu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	ctx.poll()
+
+	ctx.mu.Lock()
+	defer ctx.mu.Unlock()
+	return ctx.err
+}
+
+// Value implements context.Context
+func (ctx *mergedNContext) Value(key any) any {
+	for _, c := range ctx.ctxs {
+		if v := c.Value(key); v != nil {
+			return v
+		}
+	}
+	return nil
+}
+
+// ContextMergeCause returns an index of the context which caused cancellation
+// of the merged context and its cause (see context.Cause on Go 1.20+).
+// ctx must be returned by ContextMerge or ContextMergeN.
+//
+// Returns -1 and context.Canceled if ContextMergeN stop was called
+// and -1 and nil if ctx is not done yet or is not a merged context.
+func ContextMergeCause(ctx context.Context) (idx int, cause error) {
+	switch ctx := ctx.(type) {
+	case *mergedContext:
+		if ctx.Err() == nil {
+			return -1, nil
+		}
+		ctx.mu.Lock()
+		defer ctx.mu.Unlock()
+
+		if ctx.idx == 0 {
+			return 0, ContextCause(ctx.ctx1)
+		}
+		return 1, ContextCause(ctx.ctx2)
+
+	case *mergedNContext:
+		ctx.Err() // notice already done contexts
+		ctx.mu.Lock()
+		defer ctx.mu.Unlock()
+
+		switch {
+		case ctx.err == nil:
+		case ctx.idx == -1:
+			return -1, ctx.err
+		default:
+			return ctx.idx, ContextCause(ctx.ctxs[ctx.idx])
+		}
+	}
+	return -1, nil
+}
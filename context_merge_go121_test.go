@@ -0,0 +1,32 @@
+//go:build go1.21
+
+package synx
+
+import (
+	"context"
+	"runtime"
+	"testing"
+)
+
+func TestContextMergeNoGoroutine(t *testing.T) {
+	ctx1, cancel1 := context.WithCancel(context.Background())
+	defer cancel1()
+	ctx2, cancel2 := context.WithCancel(context.Background())
+	defer cancel2()
+
+	before := runtime.NumGoroutine()
+	ctxs := make([]context.Context, 100)
+	for i := range ctxs {
+		ctxs[i] = ContextMerge(ctx1, ctx2)
+		_ = ctxs[i].Done()
+	}
+
+	if after := runtime.NumGoroutine(); after > before+10 {
+		t.Fatalf("got %v goroutines, want at most %v", after, before+10)
+	}
+
+	cancel2()
+	for _, ctx := range ctxs {
+		waitFor(t, ctx.Done())
+	}
+}
@@ -202,6 +202,171 @@ func TestErrRace(t *testing.T) {
 	waitFor(t, done)
 }
 
+func TestContextMergeN(t *testing.T) {
+	ctx1, cancel1 := context.WithCancel(context.WithValue(context.Background(), "foo", "bar"))
+	defer cancel1()
+	ctx2, cancel2 := context.WithCancel(context.Background())
+	ctx3 := context.WithValue(context.Background(), "baz", "qux")
+
+	ctx, stop := ContextMergeN(ctx1, ctx2, ctx3)
+	defer stop()
+
+	if val := ctx.Value("foo"); val != "bar" {
+		t.Fatalf("got %v, want %v", val, "bar")
+	}
+	if val := ctx.Value("baz"); val != "qux" {
+		t.Fatalf("got %v, want %v", val, "qux")
+	}
+	if err := ctx.Err(); err != nil {
+		t.Fatalf("got %v, want %v", err, nil)
+	}
+	if idx, cause := ContextMergeCause(ctx); idx != -1 || cause != nil {
+		t.Fatalf("got (%v, %v), want (%v, %v)", idx, cause, -1, nil)
+	}
+
+	cancel2()
+	waitFor(t, ctx.Done())
+
+	if err := ctx.Err(); !errors.Is(err, context.Canceled) {
+		t.Fatalf("got %v, want %v", err, context.Canceled)
+	}
+	if idx, cause := ContextMergeCause(ctx); idx != 1 || !errors.Is(cause, context.Canceled) {
+		t.Fatalf("got (%v, %v), want (%v, %v)", idx, cause, 1, context.Canceled)
+	}
+}
+
+func TestContextMergeNDeadline(t *testing.T) {
+	t1 := time.Now().Add(10 * time.Second)
+	ctx1, cancel1 := context.WithDeadline(context.Background(), t1)
+	defer cancel1()
+
+	ctx2, cancel2 := context.WithTimeout(context.Background(), testDelay)
+	defer cancel2()
+
+	ctx, stop := ContextMergeN(ctx1, ctx2)
+	defer stop()
+
+	deadline, ok := ctx.Deadline()
+	if !ok || !deadline.Before(t1) {
+		t.Fatalf("got %v, want before %v", deadline, t1)
+	}
+
+	waitFor(t, ctx.Done())
+
+	if err := ctx.Err(); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("got %v, want %v", err, context.DeadlineExceeded)
+	}
+}
+
+func TestContextMergeNStop(t *testing.T) {
+	ctx1, cancel1 := context.WithCancel(context.Background())
+	defer cancel1()
+	ctx2, cancel2 := context.WithCancel(context.Background())
+	defer cancel2()
+
+	ctx, stop := ContextMergeN(ctx1, ctx2)
+	stop()
+	stop()
+
+	waitFor(t, ctx.Done())
+
+	if err := ctx.Err(); !errors.Is(err, context.Canceled) {
+		t.Fatalf("got %v, want %v", err, context.Canceled)
+	}
+	if idx, _ := ContextMergeCause(ctx); idx != -1 {
+		t.Fatalf("got %v, want %v", idx, -1)
+	}
+}
+
+func TestContextMergeNAlreadyDone(t *testing.T) {
+	ctx1, cancel1 := context.WithCancel(context.Background())
+	cancel1()
+
+	ctx, stop := ContextMergeN(context.Background(), ctx1)
+	defer stop()
+
+	waitFor(t, ctx.Done())
+
+	if idx, _ := ContextMergeCause(ctx); idx != 1 {
+		t.Fatalf("got %v, want %v", idx, 1)
+	}
+}
+
+func TestContextMergeCause(t *testing.T) {
+	errFoo := errors.New("foo")
+
+	ctx1, cancel1 := ContextWithCancelCause(context.Background())
+	ctx2, cancel2 := ContextWithCancelCause(context.Background())
+	defer cancel2(nil)
+
+	ctx, stop := ContextMergeN(ctx1, ctx2)
+	defer stop()
+
+	cancel1(errFoo)
+	waitFor(t, ctx.Done())
+
+	if idx, cause := ContextMergeCause(ctx); idx != 0 || !errors.Is(cause, errFoo) {
+		t.Fatalf("got (%v, %v), want (%v, %v)", idx, cause, 0, errFoo)
+	}
+}
+
+func TestContextMergeCause2(t *testing.T) {
+	errFirst, errSecond := errors.New("first"), errors.New("second")
+
+	ctx1, cancel1 := ContextWithCancelCause(context.Background())
+	ctx2, cancel2 := ContextWithCancelCause(context.Background())
+
+	ctx := ContextMerge(ctx1, ctx2)
+	if idx, cause := ContextMergeCause(ctx); idx != -1 || cause != nil {
+		t.Fatalf("got (%v, %v), want (%v, %v)", idx, cause, -1, nil)
+	}
+
+	cancel2(errSecond)
+	waitFor(t, ctx.Done())
+	cancel1(errFirst)
+
+	if idx, cause := ContextMergeCause(ctx); idx != 1 || !errors.Is(cause, errSecond) {
+		t.Fatalf("got (%v, %v), want (%v, %v)", idx, cause, 1, errSecond)
+	}
+	if err := ctx.Err(); !errors.Is(err, context.Canceled) {
+		t.Fatalf("got %v, want %v", err, context.Canceled)
+	}
+}
+
+func TestContextMergeNErrImmediately(t *testing.T) {
+	for i := 0; i < 100; i++ {
+		ctx1, cancel1 := context.WithCancel(context.Background())
+		ctx2, cancel2 := context.WithCancel(context.Background())
+
+		ctx, stop := ContextMergeN(ctx1, ctx2)
+		cancel2()
+
+		if err := ctx.Err(); !errors.Is(err, context.Canceled) {
+			t.Fatalf("got %v, want %v", err, context.Canceled)
+		}
+		stop()
+
+		if idx, cause := ContextMergeCause(ctx); idx != 1 || !errors.Is(cause, context.Canceled) {
+			t.Fatalf("got (%v, %v), want (%v, %v)", idx, cause, 1, context.Canceled)
+		}
+		cancel1()
+	}
+}
+
+func TestContextMergeNStopAfterCancel(t *testing.T) {
+	for i := 0; i < 100; i++ {
+		ctx1, cancel1 := context.WithCancel(context.Background())
+		ctx, stop := ContextMergeN(ctx1, context.Background())
+
+		cancel1()
+		stop()
+
+		if idx, _ := ContextMergeCause(ctx); idx != 0 {
+			t.Fatalf("got %v, want %v", idx, 0)
+		}
+	}
+}
+
 func waitFor(t *testing.T, ch <-chan struct{}) {
 	select {
 	case <-ch:
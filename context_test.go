@@ -2,6 +2,8 @@ package synx
 
 import (
 	"context"
+	"errors"
+	"runtime"
 	"testing"
 	"time"
 )
@@ -50,6 +52,114 @@ func TestContextFromSignal(t *testing.T) {
 	}
 }
 
+func TestContextFromSignalOptions(t *testing.T) {
+	errShutdown := errors.New("shutdown")
+	parent := context.WithValue(context.Background(), "foo", "bar")
+
+	ch := make(chan struct{})
+	ctx := ContextFromSignal(ch, SignalParent(parent), SignalErr(errShutdown))
+
+	if got := ctx.Value("foo"); got != "bar" {
+		t.Fatalf("got %v, want %v", got, "bar")
+	}
+	if err := ctx.Err(); err != nil {
+		t.Fatal(err)
+	}
+
+	close(ch)
+	waitFor(t, ctx.Done())
+
+	if err := ctx.Err(); !errors.Is(err, errShutdown) {
+		t.Fatalf("got %v, want %v", err, errShutdown)
+	}
+	if got := DumpContext(ctx); got["foo"] != "bar" {
+		t.Fatalf("got %v, want %v", got, "bar")
+	}
+}
+
+func TestContextFromSignalWithDeadline(t *testing.T) {
+	deadline := time.Now().Add(testDelay)
+	ctx, cancel := ContextFromSignalWithDeadline(make(chan struct{}), deadline)
+	defer cancel()
+
+	if got, ok := ctx.Deadline(); !ok || !got.Equal(deadline) {
+		t.Fatalf("got (%v, %v), want (%v, %v)", got, ok, deadline, true)
+	}
+
+	waitFor(t, ctx.Done())
+
+	if err := ctx.Err(); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("got %v, want %v", err, context.DeadlineExceeded)
+	}
+
+	errShutdown := errors.New("shutdown")
+	ch := make(chan struct{})
+	ctx, cancel = ContextFromSignalWithDeadline(ch, time.Now().Add(time.Hour), SignalErr(errShutdown))
+	defer cancel()
+	close(ch)
+
+	waitFor(t, ctx.Done())
+
+	if err := ctx.Err(); !errors.Is(err, errShutdown) {
+		t.Fatalf("got %v, want %v", err, errShutdown)
+	}
+}
+
+func TestContextFromSignalWithDeadlineCancel(t *testing.T) {
+	before := runtime.NumGoroutine()
+
+	cancels := make([]context.CancelFunc, 100)
+	for i := range cancels {
+		_, cancels[i] = ContextFromSignalWithDeadline(make(chan struct{}), time.Now().Add(time.Hour))
+	}
+	ctx, cancel := ContextFromSignalWithDeadline(make(chan struct{}), time.Now().Add(time.Hour))
+	for _, cancel := range cancels {
+		cancel()
+	}
+	cancel()
+
+	waitFor(t, ctx.Done())
+	if err := ctx.Err(); !errors.Is(err, context.Canceled) {
+		t.Fatalf("got %v, want %v", err, context.Canceled)
+	}
+
+	deadline := time.Now().Add(time.Second)
+	for runtime.NumGoroutine() > before && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+	if after := runtime.NumGoroutine(); after > before {
+		t.Fatalf("got %v goroutines, want at most %v", after, before)
+	}
+}
+
+func TestContextFromSignalNilErr(t *testing.T) {
+	ch := make(chan struct{})
+	ctx := ContextFromSignal(ch, SignalErr(nil))
+	close(ch)
+
+	if err := ctx.Err(); !errors.Is(err, context.Canceled) {
+		t.Fatalf("got %v, want %v", err, context.Canceled)
+	}
+}
+
+func TestSignalFromContext(t *testing.T) {
+	ch := SignalFromContext(context.Background())
+	if ch == nil {
+		t.Fatal("must not be nil")
+	}
+	select {
+	case <-ch:
+		t.Fatal("must not be closed")
+	default:
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	ch = SignalFromContext(ctx)
+	cancel()
+
+	waitFor(t, ch)
+}
+
 func TestWithCancel(t *testing.T) {
 	ctx, cancel := WithCancel()
 
@@ -105,6 +215,37 @@ func TestContextWithoutValues(t *testing.T) {
 	}
 }
 
+func TestContextWithOnlyValues(t *testing.T) {
+	ctx := context.WithValue(context.Background(), "foo", "bar")
+	ctx = context.WithValue(ctx, "baz", "qux")
+	ctx = ContextWithOnlyValues(ctx, "foo")
+
+	if got := ctx.Value("foo"); got != "bar" {
+		t.Fatalf("got %v, want %v", got, "bar")
+	}
+	if got := ctx.Value("baz"); got != nil {
+		t.Fatalf("got %v, want %v", got, nil)
+	}
+
+	ctx = context.WithValue(ctx, "baz", "new")
+	if got := ctx.Value("baz"); got != "new" {
+		t.Fatalf("got %v, want %v", got, "new")
+	}
+}
+
+func TestContextWithoutKeys(t *testing.T) {
+	ctx := context.WithValue(context.Background(), "foo", "bar")
+	ctx = context.WithValue(ctx, "baz", "qux")
+	ctx = ContextWithoutKeys(ctx, "foo")
+
+	if got := ctx.Value("foo"); got != nil {
+		t.Fatalf("got %v, want %v", got, nil)
+	}
+	if got := ctx.Value("baz"); got != "qux" {
+		t.Fatalf("got %v, want %v", got, "qux")
+	}
+}
+
 func TestContextCtxKey(t *testing.T) {
 	type UserID int64
 	type TeamID int64
@@ -147,3 +288,115 @@ func TestContextWithValues(t *testing.T) {
 		t.Fatalf("got %v, want %v", got, nil)
 	}
 }
+
+func TestContextWithCancelCause(t *testing.T) {
+	testCases := []struct {
+		name            string
+		withCancelCause func(context.Context) (context.Context, CancelCauseFunc)
+		cause           func(context.Context) error
+	}{
+		{"exported", ContextWithCancelCause, ContextCause},
+		{"backport", withCancelCause, cause},
+	}
+
+	errFoo := errors.New("foo")
+	errBar := errors.New("bar")
+
+	for _, tc := range testCases {
+		ctx, cancel := tc.withCancelCause(context.Background())
+		if err := tc.cause(ctx); err != nil {
+			t.Fatalf("%s: got %v, want %v", tc.name, err, nil)
+		}
+
+		cancel(errFoo)
+		cancel(errBar)
+
+		if err := ctx.Err(); !errors.Is(err, context.Canceled) {
+			t.Fatalf("%s: got %v, want %v", tc.name, err, context.Canceled)
+		}
+		if err := tc.cause(ctx); !errors.Is(err, errFoo) {
+			t.Fatalf("%s: got %v, want %v", tc.name, err, errFoo)
+		}
+
+		parent, cancelParent := tc.withCancelCause(context.Background())
+		child, cancelChild := tc.withCancelCause(parent)
+		cancelParent(errBar)
+		cancelChild(errFoo)
+
+		if err := tc.cause(child); !errors.Is(err, errBar) {
+			t.Fatalf("%s: got %v, want %v", tc.name, err, errBar)
+		}
+
+		ctx, cancel = tc.withCancelCause(context.Background())
+		cancel(nil)
+
+		if err := tc.cause(ctx); !errors.Is(err, context.Canceled) {
+			t.Fatalf("%s: got %v, want %v", tc.name, err, context.Canceled)
+		}
+	}
+}
+
+func TestContextWithoutCancel(t *testing.T) {
+	testCases := []struct {
+		name          string
+		withoutCancel func(context.Context) context.Context
+	}{
+		{"exported", ContextWithoutCancel},
+		{"backport", withoutCancel},
+	}
+
+	for _, tc := range testCases {
+		parent, cancel := context.WithTimeout(context.WithValue(context.Background(), "foo", "bar"), time.Hour)
+		ctx := tc.withoutCancel(parent)
+		cancel()
+
+		if err := ctx.Err(); err != nil {
+			t.Fatalf("%s: got %v, want %v", tc.name, err, nil)
+		}
+		if ctx.Done() != nil {
+			t.Fatalf("%s: must not be cancellable", tc.name)
+		}
+		if _, ok := ctx.Deadline(); ok {
+			t.Fatalf("%s: must not have deadline", tc.name)
+		}
+		if got := ctx.Value("foo"); got != "bar" {
+			t.Fatalf("%s: got %v, want %v", tc.name, got, "bar")
+		}
+	}
+}
+
+func TestContextAfterFunc(t *testing.T) {
+	testCases := []struct {
+		name      string
+		afterFunc func(context.Context, func()) func() bool
+	}{
+		{"exported", ContextAfterFunc},
+		{"backport", afterFunc},
+	}
+
+	for _, tc := range testCases {
+		ctx, cancel := context.WithCancel(context.Background())
+
+		called := make(chan struct{})
+		stop := tc.afterFunc(ctx, func() { close(called) })
+		cancel()
+
+		waitFor(t, called)
+
+		if stop() {
+			t.Fatalf("%s: must not be stopped after call", tc.name)
+		}
+
+		ctx, cancel = context.WithCancel(context.Background())
+		stop = tc.afterFunc(ctx, func() { t.Errorf("%s: must not be called", tc.name) })
+
+		if !stop() {
+			t.Fatalf("%s: must be stopped", tc.name)
+		}
+		if stop() {
+			t.Fatalf("%s: must be stopped once", tc.name)
+		}
+		cancel()
+		time.Sleep(testDelay / 10)
+	}
+}
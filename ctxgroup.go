@@ -10,8 +10,10 @@ type ContextGroup struct {
 	ctx     context.Context
 	cancel  context.CancelFunc
 	wg      sync.WaitGroup
+	sem     chan struct{}
 	errOnce sync.Once
 	err     error
+	errs    *ErrorCollector
 }
 
 // NewContextGroup returns new ContextGroup.
@@ -24,31 +26,132 @@ func NewContextGroup(parent context.Context) *ContextGroup {
 	}
 }
 
+// SetLimit limits the number of active goroutines in this group to at most n.
+// A negative value indicates no limit.
+//
+// Go will block until a goroutine can be added without exceeding the limit
+// or the group context is done.
+//
+// The limit must not be modified while any goroutines in the group are active.
+func (cg *ContextGroup) SetLimit(n int) {
+	if n < 0 {
+		cg.sem = nil
+		return
+	}
+	if len(cg.sem) != 0 {
+		panic("synx: modify limit while goroutines in the group are still active")
+	}
+	cg.sem = make(chan struct{}, n)
+}
+
+// SetCollectAll switches the group between fail-fast (default) and collect-all modes.
+//
+// In fail-fast mode the first error cancels the group and is returned by WaitErr.
+// In collect-all mode errors do not cancel the group
+// and WaitErr returns all of them as a *MultiError.
+//
+// The mode must not be modified while any goroutines in the group are active.
+func (cg *ContextGroup) SetCollectAll(collectAll bool) {
+	if !collectAll {
+		cg.errs = nil
+		return
+	}
+	if cg.errs == nil {
+		cg.errs = NewErrorCollector(0, false)
+	}
+}
+
 // Go calls the given function in a new goroutine.
+// Panic in f is recovered and reported by WaitErr as *PanicError.
+// If the limit is reached Go blocks until a slot is freed.
+// If the limit is set and the group context is done before a slot is acquired,
+// f is not called and the context error is reported by WaitErr (if there is no other error).
 func (cg *ContextGroup) Go(f func(context.Context) error) {
+	if cg.sem != nil {
+		if err := cg.ctx.Err(); err != nil {
+			cg.setErr(err)
+			return
+		}
+
+		select {
+		case cg.sem <- struct{}{}:
+		case <-cg.ctx.Done():
+			cg.setErr(cg.ctx.Err())
+			return
+		}
+
+		// select picks a random case if both are ready
+		if err := cg.ctx.Err(); err != nil {
+			<-cg.sem
+			cg.setErr(err)
+			return
+		}
+	}
+	cg.start(f)
+}
+
+// TryGo calls the given function in a new goroutine only if the number of
+// active goroutines in the group is currently below the configured limit.
+//
+// The return value reports whether the goroutine was started.
+func (cg *ContextGroup) TryGo(f func(context.Context) error) bool {
+	if cg.sem != nil {
+		select {
+		case cg.sem <- struct{}{}:
+		default:
+			return false
+		}
+	}
+	cg.start(f)
+	return true
+}
+
+func (cg *ContextGroup) start(f func(context.Context) error) {
 	cg.wg.Add(1)
+	sem := cg.sem
 
 	go func() {
-		defer cg.wg.Done()
+		defer func() {
+			if sem != nil {
+				<-sem
+			}
+			cg.wg.Done()
+		}()
 
-		if err := f(cg.ctx); err != nil {
-			cg.errOnce.Do(func() {
-				cg.err = err
-				cg.cancel()
-			})
+		var err error
+		if perr := catchPanic(func() { err = f(cg.ctx) }); perr != nil {
+			err = perr
+		}
+		if err != nil {
+			cg.setErr(err)
 		}
 	}()
 }
 
+func (cg *ContextGroup) setErr(err error) {
+	if cg.errs != nil {
+		cg.errs.Add(err)
+		return
+	}
+	cg.errOnce.Do(func() {
+		cg.err = err
+		cg.cancel()
+	})
+}
+
 // Cancel cancels all goroutines in the group.
 func (cg *ContextGroup) Cancel() {
 	cg.cancel()
 }
 
 // WaitErr blocks until all function calls have returned.
-// Returns the first non-nil error (if any).
+// Returns the first non-nil error (if any) or all errors as a *MultiError
+// in collect-all mode (see SetCollectAll).
 func (cg *ContextGroup) WaitErr() error {
 	cg.wg.Wait()
 	cg.cancel()
+	if cg.errs != nil {
+		return cg.errs.Err()
+	}
 	return cg.err
 }
@@ -0,0 +1,195 @@
+package synx
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestContextGroup(t *testing.T) {
+	errFoo := errors.New("foo")
+
+	cg := NewContextGroup(context.Background())
+	cg.Go(func(ctx context.Context) error {
+		return errFoo
+	})
+	cg.Go(func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	})
+
+	if err := cg.WaitErr(); !errors.Is(err, errFoo) {
+		t.Fatalf("got %v, want %v", err, errFoo)
+	}
+}
+
+func TestContextGroupSetLimit(t *testing.T) {
+	const limit = 3
+
+	cg := NewContextGroup(context.Background())
+	cg.SetLimit(limit)
+
+	var active, maxActive int32
+	for i := 0; i < 20; i++ {
+		cg.Go(func(ctx context.Context) error {
+			n := atomic.AddInt32(&active, 1)
+			defer atomic.AddInt32(&active, -1)
+
+			for {
+				m := atomic.LoadInt32(&maxActive)
+				if n <= m || atomic.CompareAndSwapInt32(&maxActive, m, n) {
+					break
+				}
+			}
+			time.Sleep(time.Millisecond)
+			return nil
+		})
+	}
+
+	if err := cg.WaitErr(); err != nil {
+		t.Fatal(err)
+	}
+	if maxActive > limit {
+		t.Fatalf("got %v, want no more than %v", maxActive, limit)
+	}
+}
+
+func TestContextGroupSetLimitCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	cg := NewContextGroup(ctx)
+	cg.SetLimit(1)
+
+	release := make(chan struct{})
+	cg.Go(func(ctx context.Context) error {
+		<-release
+		return nil
+	})
+
+	time.AfterFunc(testDelay, cancel)
+
+	var called int32
+	cg.Go(func(ctx context.Context) error {
+		atomic.AddInt32(&called, 1)
+		return nil
+	})
+	close(release)
+
+	if err := cg.WaitErr(); !errors.Is(err, context.Canceled) {
+		t.Fatalf("got %v, want %v", err, context.Canceled)
+	}
+	if called != 0 {
+		t.Fatal("must not be called")
+	}
+}
+
+func TestContextGroupSetLimitCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	cg := NewContextGroup(ctx)
+	cg.SetLimit(5)
+
+	var called int32
+	for i := 0; i < 200; i++ {
+		cg.Go(func(ctx context.Context) error {
+			atomic.AddInt32(&called, 1)
+			return nil
+		})
+	}
+
+	if err := cg.WaitErr(); !errors.Is(err, context.Canceled) {
+		t.Fatalf("got %v, want %v", err, context.Canceled)
+	}
+	if n := atomic.LoadInt32(&called); n != 0 {
+		t.Fatalf("got %v, want %v", n, 0)
+	}
+}
+
+func TestContextGroupTryGo(t *testing.T) {
+	cg := NewContextGroup(context.Background())
+	cg.SetLimit(1)
+
+	release := make(chan struct{})
+	if !cg.TryGo(func(ctx context.Context) error {
+		<-release
+		return nil
+	}) {
+		t.Fatal("must be started")
+	}
+
+	if cg.TryGo(func(ctx context.Context) error { return nil }) {
+		t.Fatal("must not be started")
+	}
+	close(release)
+
+	if err := cg.WaitErr(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestContextGroupCollectAll(t *testing.T) {
+	errFoo, errBar := errors.New("foo"), errors.New("bar")
+
+	cg := NewContextGroup(context.Background())
+	cg.SetCollectAll(true)
+
+	cg.Go(func(ctx context.Context) error {
+		return errFoo
+	})
+	cg.Go(func(ctx context.Context) error {
+		return errBar
+	})
+	cg.Go(func(ctx context.Context) error {
+		time.Sleep(testDelay)
+		return ctx.Err()
+	})
+
+	err := cg.WaitErr()
+
+	var me *MultiError
+	if !errors.As(err, &me) {
+		t.Fatalf("got %T, want %T", err, me)
+	}
+	if len(me.Errors) != 2 {
+		t.Fatalf("got %v, want %v", len(me.Errors), 2)
+	}
+	if !errors.Is(err, errFoo) || !errors.Is(err, errBar) {
+		t.Fatalf("got %v, want both %v and %v", err, errFoo, errBar)
+	}
+}
+
+func TestContextGroupCollectAllNoErrors(t *testing.T) {
+	cg := NewContextGroup(context.Background())
+	cg.SetCollectAll(true)
+
+	cg.Go(func(ctx context.Context) error {
+		return nil
+	})
+
+	if err := cg.WaitErr(); err != nil {
+		t.Fatalf("got %v, want %v", err, nil)
+	}
+}
+
+func TestContextGroupPanic(t *testing.T) {
+	cg := NewContextGroup(context.Background())
+	cg.Go(func(ctx context.Context) error {
+		panic("oops")
+	})
+
+	err := cg.WaitErr()
+
+	var perr *PanicError
+	if !errors.As(err, &perr) {
+		t.Fatalf("got %T, want %T", err, perr)
+	}
+	if perr.Value != "oops" {
+		t.Fatalf("got %v, want %v", perr.Value, "oops")
+	}
+	if len(perr.Stack) == 0 {
+		t.Fatal("stack must be set")
+	}
+}
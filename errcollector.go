@@ -0,0 +1,89 @@
+package synx
+
+import (
+	"sync"
+)
+
+// ErrorCollector collects errors from many goroutines.
+// Use Err to get all collected errors as a *MultiError.
+type ErrorCollector struct {
+	mu      sync.Mutex
+	limit   int
+	dedup   bool
+	errs    []error
+	seen    map[string]struct{}
+	dropped int
+}
+
+// NewErrorCollector returns a new ErrorCollector.
+// No more than limit errors are stored, other are dropped (0 means no limit).
+// If dedup is true errors with the same message are stored once.
+func NewErrorCollector(limit int, dedup bool) *ErrorCollector {
+	if limit < 0 {
+		panic("synx: limit cannot be negative")
+	}
+
+	ec := &ErrorCollector{
+		limit: limit,
+		dedup: dedup,
+	}
+	if dedup {
+		ec.seen = map[string]struct{}{}
+	}
+	return ec
+}
+
+// Add the error to the collector. Nil errors are ignored, MultiErrors are flattened.
+// Safe for concurrent use.
+func (ec *ErrorCollector) Add(err error) {
+	if err == nil {
+		return
+	}
+	errs := appendFlatten(nil, err)
+
+	ec.mu.Lock()
+	defer ec.mu.Unlock()
+
+	for _, err := range errs {
+		if ec.dedup {
+			msg := err.Error()
+			if _, ok := ec.seen[msg]; ok {
+				continue
+			}
+			ec.seen[msg] = struct{}{}
+		}
+
+		if ec.limit > 0 && len(ec.errs) >= ec.limit {
+			ec.dropped++
+			continue
+		}
+		ec.errs = append(ec.errs, err)
+	}
+}
+
+// Len returns the number of stored errors.
+func (ec *ErrorCollector) Len() int {
+	ec.mu.Lock()
+	defer ec.mu.Unlock()
+	return len(ec.errs)
+}
+
+// Dropped returns the number of errors dropped due to the limit.
+func (ec *ErrorCollector) Dropped() int {
+	ec.mu.Lock()
+	defer ec.mu.Unlock()
+	return ec.dropped
+}
+
+// Err returns collected errors as a *MultiError or nil if there are none.
+func (ec *ErrorCollector) Err() error {
+	ec.mu.Lock()
+	defer ec.mu.Unlock()
+
+	if len(ec.errs) == 0 {
+		return nil
+	}
+	errs := make([]error, len(ec.errs))
+	copy(errs, ec.errs)
+	return &MultiError{Errors: errs}
+}
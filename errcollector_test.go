@@ -0,0 +1,63 @@
+package synx
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestErrorCollector(t *testing.T) {
+	ec := NewErrorCollector(0, false)
+	if err := ec.Err(); err != nil {
+		t.Fatalf("got %v, want %v", err, nil)
+	}
+
+	wg := NewWaitGroup()
+	for i := 0; i < 100; i++ {
+		i := i
+		wg.Go(func() {
+			if i%2 == 0 {
+				ec.Add(fmt.Errorf("error %d", i))
+			} else {
+				ec.Add(nil)
+			}
+		})
+	}
+	wg.Wait()
+
+	var me *MultiError
+	if !errors.As(ec.Err(), &me) {
+		t.Fatalf("got %T, want %T", ec.Err(), me)
+	}
+	if len(me.Errors) != 50 {
+		t.Fatalf("got %v, want %v", len(me.Errors), 50)
+	}
+	if ec.Dropped() != 0 {
+		t.Fatalf("got %v, want %v", ec.Dropped(), 0)
+	}
+}
+
+func TestErrorCollectorLimit(t *testing.T) {
+	ec := NewErrorCollector(3, false)
+	for i := 0; i < 10; i++ {
+		ec.Add(fmt.Errorf("error %d", i))
+	}
+
+	if ec.Len() != 3 {
+		t.Fatalf("got %v, want %v", ec.Len(), 3)
+	}
+	if ec.Dropped() != 7 {
+		t.Fatalf("got %v, want %v", ec.Dropped(), 7)
+	}
+}
+
+func TestErrorCollectorDedup(t *testing.T) {
+	ec := NewErrorCollector(0, true)
+	ec.Add(errors.New("foo"))
+	ec.Add(errors.New("foo"))
+	ec.Add(&MultiError{Errors: []error{errors.New("bar"), errors.New("foo")}})
+
+	if ec.Len() != 2 {
+		t.Fatalf("got %v, want %v", ec.Len(), 2)
+	}
+}
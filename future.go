@@ -0,0 +1,77 @@
+package synx
+
+import (
+	"context"
+)
+
+// Future is a result of a task submitted to WorkerPool. See Submit.
+type Future[T any] struct {
+	done   chan struct{}
+	value  T
+	err    error
+	cancel context.CancelFunc
+}
+
+// Submit runs fn on the worker pool and returns a Future for its result.
+// Blocks like WorkerPool.DoContext till the task is accepted.
+//
+// Context passed to fn is cancelled when ctx is done or Future.Cancel is called.
+// If the task cannot be submitted, the future is completed with the error.
+// Panic in fn is recovered and returned as *PanicError.
+func Submit[T any](wp *WorkerPool, ctx context.Context, fn func(context.Context) (T, error)) *Future[T] {
+	taskCtx, cancel := context.WithCancel(ctx)
+
+	f := &Future[T]{
+		done:   make(chan struct{}),
+		cancel: cancel,
+	}
+
+	err := wp.DoContext(taskCtx, func() {
+		f.run(taskCtx, fn)
+	})
+	if err != nil {
+		var zero T
+		f.complete(zero, err)
+	}
+	return f
+}
+
+func (f *Future[T]) run(ctx context.Context, fn func(context.Context) (T, error)) {
+	var value T
+	var err error
+
+	if err = ctx.Err(); err == nil {
+		if perr := catchPanic(func() { value, err = fn(ctx) }); perr != nil {
+			err = perr
+		}
+	}
+	f.complete(value, err)
+}
+
+func (f *Future[T]) complete(value T, err error) {
+	f.value, f.err = value, err
+	f.cancel()
+	close(f.done)
+}
+
+// Get waits for the result. Returns ctx error if ctx is done first.
+func (f *Future[T]) Get(ctx context.Context) (T, error) {
+	select {
+	case <-f.done:
+		return f.value, f.err
+	case <-ctx.Done():
+		var zero T
+		return zero, ctx.Err()
+	}
+}
+
+// Done returns a channel that is closed when the result is ready.
+func (f *Future[T]) Done() <-chan struct{} {
+	return f.done
+}
+
+// Cancel the task. Context passed to the task is cancelled.
+// The task is not called if it has not started yet.
+func (f *Future[T]) Cancel() {
+	f.cancel()
+}
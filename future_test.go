@@ -0,0 +1,91 @@
+package synx
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestSubmit(t *testing.T) {
+	wp := NewWorkerPool(2, time.Minute)
+	defer wp.Close()
+
+	f := Submit(wp, context.Background(), func(ctx context.Context) (int, error) {
+		return 42, nil
+	})
+
+	waitFor(t, f.Done())
+
+	v, err := f.Get(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v != 42 {
+		t.Fatalf("got %v, want %v", v, 42)
+	}
+}
+
+func TestSubmitCancel(t *testing.T) {
+	wp := NewWorkerPool(1, time.Minute)
+	defer wp.Close()
+
+	started := make(chan struct{})
+	f := Submit(wp, context.Background(), func(ctx context.Context) (int, error) {
+		close(started)
+		<-ctx.Done()
+		return 0, ctx.Err()
+	})
+
+	waitFor(t, started)
+	f.Cancel()
+
+	if _, err := f.Get(context.Background()); !errors.Is(err, context.Canceled) {
+		t.Fatalf("got %v, want %v", err, context.Canceled)
+	}
+}
+
+func TestSubmitGetTimeout(t *testing.T) {
+	wp := NewWorkerPool(1, time.Minute)
+	defer wp.Close()
+
+	release := make(chan struct{})
+	f := Submit(wp, context.Background(), func(ctx context.Context) (string, error) {
+		<-release
+		return "done", nil
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), testDelay)
+	defer cancel()
+
+	if _, err := f.Get(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("got %v, want %v", err, context.DeadlineExceeded)
+	}
+	close(release)
+
+	if v, err := f.Get(context.Background()); err != nil || v != "done" {
+		t.Fatalf("got (%v, %v), want (%v, %v)", v, err, "done", nil)
+	}
+}
+
+func TestSubmitErrors(t *testing.T) {
+	wp := NewWorkerPool(1, time.Minute)
+
+	f := Submit(wp, context.Background(), func(ctx context.Context) (int, error) {
+		panic("oops")
+	})
+
+	var perr *PanicError
+	if _, err := f.Get(context.Background()); !errors.As(err, &perr) {
+		t.Fatalf("got %v, want %T", err, perr)
+	}
+
+	wp.Close()
+
+	f = Submit(wp, context.Background(), func(ctx context.Context) (int, error) {
+		return 1, nil
+	})
+	if _, err := f.Get(context.Background()); !errors.Is(err, ErrWorkerPoolClosed) {
+		t.Fatalf("got %v, want %v", err, ErrWorkerPoolClosed)
+	}
+}
@@ -2,8 +2,7 @@ package synx
 
 import (
 	"context"
-	"fmt"
-	"strings"
+	"sync/atomic"
 	"time"
 )
 
@@ -14,10 +13,10 @@ type HedgedWorker interface {
 	Execute(ctx context.Context, input any) (result any, err error)
 }
 
-// NewHedger returns a new http.RoundTripper which implements hedged requests pattern.
-// Given RoundTripper starts a new request after a timeout from previous request.
+// NewHedger returns a new Hedger which implements hedged requests pattern.
+// Given Hedger starts a new request after a timeout from previous request.
 // Starts no more than upto requests.
-func NewHedger(timeout time.Duration, upto int, worker HedgedWorker) HedgedWorker {
+func NewHedger(timeout time.Duration, upto int, worker HedgedWorker) *Hedger {
 	switch {
 	case timeout < 0:
 		panic("synx: timeout cannot be negative")
@@ -31,49 +30,106 @@ func NewHedger(timeout time.Duration, upto int, worker HedgedWorker) HedgedWorke
 		timeout = time.Nanosecond // smallest possible timeout if not set
 	}
 
-	hedged := &hedgedWorker{
+	hedged := &Hedger{
 		worker:  worker,
 		timeout: timeout,
 		upto:    upto,
 		wp:      NewWorkerPool(10, time.Minute),
+		wins:    make([]int64, upto),
 	}
 	return hedged
 }
 
-type hedgedWorker struct {
+// Hedger is a HedgedWorker which implements hedged requests pattern.
+// See NewHedger.
+type Hedger struct {
 	worker  HedgedWorker
 	timeout time.Duration
 	upto    int
 	wp      *WorkerPool
+
+	launched  int64
+	cancelled int64
+	allFailed int64
+	wins      []int64
 }
 
-func (ht *hedgedWorker) Execute(ctx context.Context, input any) (any, error) {
+// HedgerStats is a snapshot of Hedger counters.
+type HedgerStats struct {
+	// Launched is a total number of started attempts.
+	Launched int64
+
+	// Wins holds the number of successful results by attempt index.
+	// Wins[0] is the first attempt, Wins[1] is the first hedged attempt, etc.
+	Wins []int64
+
+	// Cancelled is a number of attempts cancelled while still in flight.
+	Cancelled int64
+
+	// AllFailed is a number of Execute calls where every attempt has failed.
+	AllFailed int64
+}
+
+// Stats returns a snapshot of the hedger counters.
+func (ht *Hedger) Stats() HedgerStats {
+	wins := make([]int64, len(ht.wins))
+	for i := range ht.wins {
+		wins[i] = atomic.LoadInt64(&ht.wins[i])
+	}
+	return HedgerStats{
+		Launched:  atomic.LoadInt64(&ht.launched),
+		Wins:      wins,
+		Cancelled: atomic.LoadInt64(&ht.cancelled),
+		AllFailed: atomic.LoadInt64(&ht.allFailed),
+	}
+}
+
+// HedgeAttempt returns the attempt index of the hedged call.
+// 0 is the first attempt, 1 is the first hedged attempt, etc.
+// The ok result is false if ctx is not passed by Hedger.
+func HedgeAttempt(ctx context.Context) (idx int, ok bool) {
+	v, ok := ctx.Value(ctxKey[hedgeAttempt]{}).(hedgeAttempt)
+	return int(v), ok
+}
+
+type hedgeAttempt int
+
+// Execute implements HedgedWorker.
+func (ht *Hedger) Execute(ctx context.Context, input any) (any, error) {
 	mainCtx := ctx
 
-	var timeout time.Duration
+	timeout := ht.timeout
 	errOverall := &MultiError{}
 	resultCh := make(chan indexedResult, ht.upto)
 	errorCh := make(chan error, ht.upto)
 
 	resultIdx := -1
 	cancels := make([]func(), ht.upto)
+	finished := make([]int32, ht.upto)
 
-	defer ht.wp.Do(func() {
+	// cancel in-flight attempts before returning, so Stats is up to date
+	defer func() {
 		for i, cancel := range cancels {
 			if i != resultIdx && cancel != nil {
+				if atomic.LoadInt32(&finished[i]) == 0 {
+					atomic.AddInt64(&ht.cancelled, 1)
+				}
 				cancel()
 			}
 		}
-	})
+	}()
 
 	for sent := 0; len(errOverall.Errors) < ht.upto; sent++ {
 		if sent < ht.upto {
 			idx := sent
 			subCtx, cancel := context.WithCancel(ctx)
+			subCtx = context.WithValue(subCtx, ctxKey[hedgeAttempt]{}, hedgeAttempt(idx))
 			cancels[idx] = cancel
+			atomic.AddInt64(&ht.launched, 1)
 
 			ht.wp.Do(func() {
 				result, err := ht.worker.Execute(subCtx, input)
+				atomic.StoreInt32(&finished[idx], 1)
 				if err != nil {
 					errorCh <- err
 				} else {
@@ -91,6 +147,7 @@ func (ht *hedgedWorker) Execute(ctx context.Context, input any) (any, error) {
 		switch {
 		case result.Result != nil:
 			resultIdx = result.Index
+			atomic.AddInt64(&ht.wins[resultIdx], 1)
 			return result.Result, nil
 		case mainCtx.Err() != nil:
 			return nil, mainCtx.Err()
@@ -100,6 +157,7 @@ func (ht *hedgedWorker) Execute(ctx context.Context, input any) (any, error) {
 	}
 
 	// all request have returned errors
+	atomic.AddInt64(&ht.allFailed, 1)
 	return nil, errOverall
 }
 
@@ -132,49 +190,3 @@ type indexedResult struct {
 	Index  int
 	Result any
 }
-
-// MultiError is an error type to track multiple errors. This is used to
-// accumulate errors in cases and return them as a single "error".
-// Insiper by https://github.com/hashicorp/go-multierror
-type MultiError struct {
-	Errors        []error
-	ErrorFormatFn ErrorFormatFunc
-}
-
-func (e *MultiError) Error() string {
-	fn := e.ErrorFormatFn
-	if fn == nil {
-		fn = listFormatFunc
-	}
-	return fn(e.Errors)
-}
-
-func (e *MultiError) String() string {
-	return fmt.Sprintf("*%#v", e.Errors)
-}
-
-// ErrorOrNil returns an error if there are some.
-func (e *MultiError) ErrorOrNil() error {
-	switch {
-	case e == nil || len(e.Errors) == 0:
-		return nil
-	default:
-		return e
-	}
-}
-
-// ErrorFormatFunc is called by MultiError to return the list of errors as a string.
-type ErrorFormatFunc func([]error) string
-
-func listFormatFunc(es []error) string {
-	if len(es) == 1 {
-		return fmt.Sprintf("1 error occurred:\n\t* %s\n\n", es[0])
-	}
-
-	points := make([]string, len(es))
-	for i, err := range es {
-		points[i] = fmt.Sprintf("* %s", err)
-	}
-
-	return fmt.Sprintf("%d errors occurred:\n\t%s\n\n", len(es), strings.Join(points, "\n\t"))
-}
@@ -0,0 +1,96 @@
+package synx
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+type hedgedWorkerFn func(ctx context.Context, input any) (any, error)
+
+func (fn hedgedWorkerFn) Execute(ctx context.Context, input any) (any, error) {
+	return fn(ctx, input)
+}
+
+func TestHedgerWaitsTimeout(t *testing.T) {
+	var calls int32
+	h := NewHedger(testDelay, 3, hedgedWorkerFn(func(ctx context.Context, input any) (any, error) {
+		atomic.AddInt32(&calls, 1)
+		time.Sleep(testDelay / 5)
+		return "ok", nil
+	}))
+
+	res, err := h.Execute(context.Background(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res != "ok" {
+		t.Fatalf("got %v, want %v", res, "ok")
+	}
+	if n := atomic.LoadInt32(&calls); n != 1 {
+		t.Fatalf("got %v, want %v", n, 1)
+	}
+}
+
+func TestHedgerStats(t *testing.T) {
+	h := NewHedger(10*time.Millisecond, 3, hedgedWorkerFn(func(ctx context.Context, input any) (any, error) {
+		idx, ok := HedgeAttempt(ctx)
+		if !ok {
+			return nil, errors.New("no attempt index")
+		}
+		if idx == 0 {
+			<-ctx.Done()
+			return nil, ctx.Err()
+		}
+		return idx, nil
+	}))
+
+	res, err := h.Execute(context.Background(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res != 1 {
+		t.Fatalf("got %v, want %v", res, 1)
+	}
+
+	stats := h.Stats()
+	if stats.Launched != 2 {
+		t.Fatalf("got %v, want %v", stats.Launched, 2)
+	}
+	if stats.Wins[1] != 1 {
+		t.Fatalf("got %v, want %v", stats.Wins, []int64{0, 1, 0})
+	}
+	if stats.Cancelled != 1 {
+		t.Fatalf("got %v, want %v", stats.Cancelled, 1)
+	}
+	if stats.AllFailed != 0 {
+		t.Fatalf("got %v, want %v", stats.AllFailed, 0)
+	}
+}
+
+func TestHedgerAllFailed(t *testing.T) {
+	errFail := errors.New("fail")
+	h := NewHedger(time.Millisecond, 2, hedgedWorkerFn(func(ctx context.Context, input any) (any, error) {
+		return nil, errFail
+	}))
+
+	if _, err := h.Execute(context.Background(), nil); err == nil {
+		t.Fatal("must fail")
+	}
+
+	stats := h.Stats()
+	if stats.Launched != 2 {
+		t.Fatalf("got %v, want %v", stats.Launched, 2)
+	}
+	if stats.AllFailed != 1 {
+		t.Fatalf("got %v, want %v", stats.AllFailed, 1)
+	}
+}
+
+func TestHedgeAttemptNoHedger(t *testing.T) {
+	if _, ok := HedgeAttempt(context.Background()); ok {
+		t.Fatal("must not be ok")
+	}
+}
@@ -0,0 +1,201 @@
+package synx
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// MultiError is an error type to track multiple errors. This is used to
+// accumulate errors in cases and return them as a single "error".
+// Insiper by https://github.com/hashicorp/go-multierror
+type MultiError struct {
+	Errors        []error
+	ErrorFormatFn ErrorFormatFunc
+}
+
+func (e *MultiError) Error() string {
+	fn := e.ErrorFormatFn
+	if fn == nil {
+		fn = ListFormatFunc
+	}
+	return fn(e.Errors)
+}
+
+func (e *MultiError) String() string {
+	return fmt.Sprintf("*%#v", e.Errors)
+}
+
+// Format implements fmt.Formatter.
+// Verb %+v prints every error with %+v, so nested causes are printed too.
+// It always uses the list layout of ListFormatFunc (without trailing blank lines)
+// to keep nesting readable, ErrorFormatFn is ignored.
+func (e *MultiError) Format(f fmt.State, verb rune) {
+	switch {
+	case verb == 'v' && f.Flag('#'):
+		fmt.Fprint(f, e.String())
+
+	case verb == 'v' && f.Flag('+'):
+		if len(e.Errors) == 1 {
+			fmt.Fprint(f, "1 error occurred:")
+		} else {
+			fmt.Fprintf(f, "%d errors occurred:", len(e.Errors))
+		}
+		for _, err := range e.Errors {
+			msg := fmt.Sprintf("%+v", err)
+			fmt.Fprintf(f, "\n\t* %s", strings.ReplaceAll(msg, "\n", "\n\t  "))
+		}
+
+	case verb == 'q':
+		fmt.Fprintf(f, "%q", e.Error())
+
+	default:
+		fmt.Fprint(f, e.Error())
+	}
+}
+
+// MarshalJSON implements json.Marshaler.
+// Errors are encoded as an array of messages.
+func (e *MultiError) MarshalJSON() ([]byte, error) {
+	msgs := make([]string, len(e.Errors))
+	for i, err := range e.Errors {
+		msgs[i] = err.Error()
+	}
+	return json.Marshal(msgs)
+}
+
+// Unwrap returns the wrapped errors.
+// Used by errors.Is and errors.As on Go 1.20+.
+func (e *MultiError) Unwrap() []error {
+	if e == nil {
+		return nil
+	}
+	return e.Errors
+}
+
+// ErrorOrNil returns an error if there are some.
+func (e *MultiError) ErrorOrNil() error {
+	switch {
+	case e == nil || len(e.Errors) == 0:
+		return nil
+	default:
+		return e
+	}
+}
+
+// Append errs to err and returns a *MultiError (or nil if there are no errors).
+// If err is a *MultiError errs are appended to it, otherwise a new one is created.
+// Nil errors are skipped and nested MultiErrors are flattened.
+func Append(err error, errs ...error) error {
+	me, ok := err.(*MultiError)
+	switch {
+	case ok && me != nil:
+		me.Errors = appendFlatten(me.Errors, errs...)
+	default:
+		me = &MultiError{
+			Errors: appendFlatten(nil, err),
+		}
+		me.Errors = appendFlatten(me.Errors, errs...)
+	}
+	return me.ErrorOrNil()
+}
+
+// Flatten nested MultiErrors into a single one.
+// Returns err as is if it is not a *MultiError.
+func Flatten(err error) error {
+	me, ok := err.(*MultiError)
+	switch {
+	case !ok:
+		return err
+	case me == nil:
+		return nil
+	}
+	flat := &MultiError{
+		Errors:        appendFlatten(nil, me.Errors...),
+		ErrorFormatFn: me.ErrorFormatFn,
+	}
+	return flat.ErrorOrNil()
+}
+
+func appendFlatten(dst []error, errs ...error) []error {
+	for _, err := range errs {
+		switch err := err.(type) {
+		case nil:
+		case *MultiError:
+			if err != nil {
+				dst = appendFlatten(dst, err.Errors...)
+			}
+		default:
+			dst = append(dst, err)
+		}
+	}
+	return dst
+}
+
+// errorsIs is errors.Is for a list of errors. Used by MultiError.Is before Go 1.20.
+func errorsIs(errs []error, target error) bool {
+	for _, err := range errs {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// errorsAs is errors.As for a list of errors. Used by MultiError.As before Go 1.20.
+func errorsAs(errs []error, target any) bool {
+	for _, err := range errs {
+		if errors.As(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// ErrorFormatFunc is called by MultiError to return the list of errors as a string.
+type ErrorFormatFunc func([]error) string
+
+// ListFormatFunc formats errors as a multi-line list. Used by default.
+func ListFormatFunc(es []error) string {
+	if len(es) == 1 {
+		return fmt.Sprintf("1 error occurred:\n\t* %s\n\n", es[0])
+	}
+
+	points := make([]string, len(es))
+	for i, err := range es {
+		points[i] = fmt.Sprintf("* %s", err)
+	}
+
+	return fmt.Sprintf("%d errors occurred:\n\t%s\n\n", len(es), strings.Join(points, "\n\t"))
+}
+
+// SingleLineFormatFunc formats errors in a single line separated by "; ".
+func SingleLineFormatFunc(es []error) string {
+	msgs := make([]string, len(es))
+	for i, err := range es {
+		msgs[i] = err.Error()
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// GroupedFormatFunc formats errors in a single line grouped by message with counts.
+// Groups are in order of the first occurrence.
+func GroupedFormatFunc(es []error) string {
+	var msgs []string
+	counts := map[string]int{}
+	for _, err := range es {
+		msg := err.Error()
+		if counts[msg] == 0 {
+			msgs = append(msgs, msg)
+		}
+		counts[msg]++
+	}
+
+	for i, msg := range msgs {
+		if n := counts[msg]; n > 1 {
+			msgs[i] = fmt.Sprintf("%s (x%d)", msg, n)
+		}
+	}
+	return strings.Join(msgs, "; ")
+}
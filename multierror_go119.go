@@ -0,0 +1,22 @@
+//go:build !go1.20
+
+package synx
+
+// Is reports whether any of the errors matches target.
+// Go 1.20+ does this via Unwrap() []error.
+func (e *MultiError) Is(target error) bool {
+	if e == nil {
+		return false
+	}
+	return errorsIs(e.Errors, target)
+}
+
+// As finds the first error that matches target, and if one is found, sets
+// target to that error value and returns true. Otherwise, it returns false.
+// Go 1.20+ does this via Unwrap() []error.
+func (e *MultiError) As(target any) bool {
+	if e == nil {
+		return false
+	}
+	return errorsAs(e.Errors, target)
+}
@@ -0,0 +1,164 @@
+package synx
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestMultiErrorIs(t *testing.T) {
+	err := Append(errors.New("foo"), context.DeadlineExceeded)
+
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("must be %v", context.DeadlineExceeded)
+	}
+	if errors.Is(err, context.Canceled) {
+		t.Fatalf("must not be %v", context.Canceled)
+	}
+}
+
+func TestMultiErrorAs(t *testing.T) {
+	err := Append(nil, errors.New("foo"), RetryableError(errors.New("bar")))
+
+	var rerr *retryableError
+	if !errors.As(err, &rerr) {
+		t.Fatal("must be retryable")
+	}
+	if got := rerr.err.Error(); got != "bar" {
+		t.Fatalf("got %v, want %v", got, "bar")
+	}
+}
+
+func TestMultiErrorIsAsFallback(t *testing.T) {
+	errs := []error{errors.New("foo"), RetryableError(context.DeadlineExceeded)}
+
+	if !errorsIs(errs, context.DeadlineExceeded) {
+		t.Fatalf("must be %v", context.DeadlineExceeded)
+	}
+	if errorsIs(errs, context.Canceled) {
+		t.Fatalf("must not be %v", context.Canceled)
+	}
+	if errorsIs(nil, context.Canceled) {
+		t.Fatalf("must not be %v", context.Canceled)
+	}
+
+	var rerr *retryableError
+	if !errorsAs(errs, &rerr) {
+		t.Fatal("must be retryable")
+	}
+	if errorsAs(nil, &rerr) {
+		t.Fatal("must not be retryable")
+	}
+}
+
+func TestMultiErrorNil(t *testing.T) {
+	var me *MultiError
+	if errors.Is(me, context.Canceled) {
+		t.Fatalf("must not be %v", context.Canceled)
+	}
+	if got := Flatten(me); got != nil {
+		t.Fatalf("got %v, want %v", got, nil)
+	}
+}
+
+func TestAppend(t *testing.T) {
+	if err := Append(nil); err != nil {
+		t.Fatalf("got %v, want %v", err, nil)
+	}
+	if err := Append(nil, nil, nil); err != nil {
+		t.Fatalf("got %v, want %v", err, nil)
+	}
+
+	err1, err2, err3 := errors.New("1"), errors.New("2"), errors.New("3")
+
+	var err error
+	err = Append(err, err1)
+	err = Append(err, nil, &MultiError{Errors: []error{err2, &MultiError{Errors: []error{err3}}}})
+
+	me, ok := err.(*MultiError)
+	if !ok {
+		t.Fatalf("got %T, want %T", err, me)
+	}
+	if len(me.Errors) != 3 {
+		t.Fatalf("got %v, want %v", len(me.Errors), 3)
+	}
+	for i, want := range []error{err1, err2, err3} {
+		if me.Errors[i] != want {
+			t.Fatalf("#%d: got %v, want %v", i, me.Errors[i], want)
+		}
+	}
+}
+
+func TestFlatten(t *testing.T) {
+	err1, err2 := errors.New("1"), errors.New("2")
+
+	if got := Flatten(err1); got != err1 {
+		t.Fatalf("got %v, want %v", got, err1)
+	}
+	if got := Flatten(&MultiError{Errors: []error{&MultiError{}}}); got != nil {
+		t.Fatalf("got %v, want %v", got, nil)
+	}
+
+	err := Flatten(&MultiError{Errors: []error{err1, &MultiError{Errors: []error{err2}}}})
+	if me := err.(*MultiError); len(me.Errors) != 2 {
+		t.Fatalf("got %v, want %v", len(me.Errors), 2)
+	}
+}
+
+func TestMultiErrorFormatFuncs(t *testing.T) {
+	errs := []error{errors.New("foo"), errors.New("bar"), errors.New("foo")}
+
+	testCases := []struct {
+		fn   ErrorFormatFunc
+		want string
+	}{
+		{ListFormatFunc, "3 errors occurred:\n\t* foo\n\t* bar\n\t* foo\n\n"},
+		{SingleLineFormatFunc, "foo; bar; foo"},
+		{GroupedFormatFunc, "foo (x2); bar"},
+	}
+
+	for i, tc := range testCases {
+		err := &MultiError{Errors: errs, ErrorFormatFn: tc.fn}
+		if got := err.Error(); got != tc.want {
+			t.Fatalf("#%d: got %q, want %q", i+1, got, tc.want)
+		}
+	}
+}
+
+func TestMultiErrorFormat(t *testing.T) {
+	err := &MultiError{
+		Errors: []error{
+			errors.New("foo"),
+			&MultiError{Errors: []error{errors.New("bar"), errors.New("baz")}},
+		},
+		ErrorFormatFn: SingleLineFormatFunc,
+	}
+
+	if got, want := fmt.Sprintf("%v", err), "foo; 2 errors occurred:\n\t* bar\n\t* baz\n\n"; got != want {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+
+	want := "2 errors occurred:\n\t* foo\n\t* 2 errors occurred:\n\t  \t* bar\n\t  \t* baz"
+	if got := fmt.Sprintf("%+v", err); got != want {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+
+	err = &MultiError{Errors: []error{errors.New("foo")}}
+	if got, want := fmt.Sprintf("%+v", err), "1 error occurred:\n\t* foo"; got != want {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+}
+
+func TestMultiErrorMarshalJSON(t *testing.T) {
+	err := Append(errors.New("foo"), errors.New("bar"))
+
+	b, errMarshal := json.Marshal(err)
+	if errMarshal != nil {
+		t.Fatal(errMarshal)
+	}
+	if got, want := string(b), `["foo","bar"]`; got != want {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,40 @@
+package synx
+
+import (
+	"fmt"
+	"runtime/debug"
+)
+
+// PanicError is a recovered panic with a stack trace.
+type PanicError struct {
+	// Value passed to panic.
+	Value any
+
+	// Stack trace of the panicked goroutine.
+	Stack []byte
+}
+
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("panic: %v\n\n%s", e.Value, e.Stack)
+}
+
+// Unwrap returns the panic value if it is an error.
+func (e *PanicError) Unwrap() error {
+	err, _ := e.Value.(error)
+	return err
+}
+
+// catchPanic calls fn and returns recovered panic (if any).
+func catchPanic(fn func()) (perr *PanicError) {
+	defer func() {
+		if r := recover(); r != nil {
+			perr = &PanicError{
+				Value: r,
+				Stack: debug.Stack(),
+			}
+		}
+	}()
+
+	fn()
+	return nil
+}
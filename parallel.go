@@ -0,0 +1,141 @@
+package synx
+
+import (
+	"context"
+)
+
+// ParallelMap calls f for every element of in with at most n concurrent calls.
+// Results are in the same order as in. The first error cancels other calls
+// and is returned with nil results.
+func ParallelMap[T, R any](ctx context.Context, in []T, n int, f func(context.Context, T) (R, error)) ([]R, error) {
+	if n < 1 {
+		panic("synx: n must be greater than 0")
+	}
+
+	out := make([]R, len(in))
+
+	cg := NewContextGroup(ctx)
+	cg.SetLimit(n)
+
+	for i := range in {
+		i := i
+		cg.Go(func(ctx context.Context) error {
+			res, err := f(ctx, in[i])
+			if err != nil {
+				return err
+			}
+			out[i] = res
+			return nil
+		})
+	}
+
+	if err := cg.WaitErr(); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ParallelForEach calls f for every element of in with at most n concurrent calls.
+// The first error cancels other calls and is returned.
+func ParallelForEach[T any](ctx context.Context, in []T, n int, f func(context.Context, T) error) error {
+	if n < 1 {
+		panic("synx: n must be greater than 0")
+	}
+
+	cg := NewContextGroup(ctx)
+	cg.SetLimit(n)
+
+	for i := range in {
+		v := in[i]
+		cg.Go(func(ctx context.Context) error {
+			return f(ctx, v)
+		})
+	}
+	return cg.WaitErr()
+}
+
+// ParallelMapChan calls f for every value from in with at most n concurrent calls.
+// Results are sent to the returned channel in the same order as values are received.
+// The first error cancels other calls.
+//
+// Returned channel is closed when in is closed and all calls have returned or on error.
+// After that the error (or nil) is sent to the returned ErrCh.
+// Results must be consumed or ctx must be cancelled to not leak goroutines.
+func ParallelMapChan[T, R any](ctx context.Context, in <-chan T, n int, f func(context.Context, T) (R, error)) (<-chan R, ErrCh) {
+	if n < 1 {
+		panic("synx: n must be greater than 0")
+	}
+
+	out := make(chan R)
+	errCh := NewErrCh()
+
+	cg := NewContextGroup(ctx)
+	cg.SetLimit(n)
+
+	// results are queued in order of values, so at most n are in flight.
+	queue := make(chan chan R, n)
+	feederDone := make(chan struct{})
+
+	go func() {
+		defer close(feederDone)
+		defer close(queue)
+
+		for {
+			var v T
+			var ok bool
+			select {
+			case v, ok = <-in:
+				if !ok {
+					return
+				}
+			case <-cg.ctx.Done():
+				return
+			}
+
+			res := make(chan R, 1)
+			select {
+			case queue <- res:
+			case <-cg.ctx.Done():
+				return
+			}
+
+			cg.Go(func(ctx context.Context) error {
+				r, err := f(ctx, v)
+				if err != nil {
+					return err
+				}
+				res <- r
+				return nil
+			})
+		}
+	}()
+
+	go func() {
+		stopped := false
+		defer func() {
+			<-feederDone
+			err := cg.WaitErr()
+			if err == nil && stopped {
+				err = ctx.Err()
+			}
+			close(out)
+			errCh.Set(err)
+		}()
+
+		for res := range queue {
+			select {
+			case r := <-res:
+				select {
+				case out <- r:
+					continue
+				case <-cg.ctx.Done():
+				}
+			case <-cg.ctx.Done():
+			}
+			stopped = true
+			return
+		}
+	}()
+
+	return out, errCh
+}
@@ -0,0 +1,129 @@
+package synx
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestParallelMap(t *testing.T) {
+	in := []int{1, 2, 3, 4, 5, 6, 7, 8}
+
+	out, err := ParallelMap(context.Background(), in, 3, func(ctx context.Context, v int) (int, error) {
+		time.Sleep(time.Duration(10-v) * time.Millisecond)
+		return v * 10, nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []int{10, 20, 30, 40, 50, 60, 70, 80}
+	if !reflect.DeepEqual(out, want) {
+		t.Fatalf("got %v, want %v", out, want)
+	}
+}
+
+func TestParallelMapError(t *testing.T) {
+	errFoo := errors.New("foo")
+	in := make([]int, 100)
+
+	var calls int32
+	out, err := ParallelMap(context.Background(), in, 2, func(ctx context.Context, v int) (int, error) {
+		if atomic.AddInt32(&calls, 1) == 3 {
+			return 0, errFoo
+		}
+		time.Sleep(time.Millisecond)
+		return v, nil
+	})
+	if !errors.Is(err, errFoo) {
+		t.Fatalf("got %v, want %v", err, errFoo)
+	}
+	if out != nil {
+		t.Fatalf("got %v, want %v", out, nil)
+	}
+	// only in-flight calls may start after the error
+	if n := atomic.LoadInt32(&calls); n > 6 {
+		t.Fatalf("got %v calls, want at most %v", n, 6)
+	}
+}
+
+func TestParallelForEach(t *testing.T) {
+	var sum int64
+	err := ParallelForEach(context.Background(), []int64{1, 2, 3, 4}, 2, func(ctx context.Context, v int64) error {
+		atomic.AddInt64(&sum, v)
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sum != 10 {
+		t.Fatalf("got %v, want %v", sum, 10)
+	}
+}
+
+func TestParallelMapChan(t *testing.T) {
+	in := make(chan int)
+	go func() {
+		defer close(in)
+		for i := 0; i < 20; i++ {
+			in <- i
+		}
+	}()
+
+	out, errCh := ParallelMapChan(context.Background(), in, 4, func(ctx context.Context, v int) (int, error) {
+		time.Sleep(time.Duration(v%3) * time.Millisecond)
+		return v * 2, nil
+	})
+
+	var got []int
+	for v := range out {
+		got = append(got, v)
+	}
+	if err := errCh.Get(); err != nil {
+		t.Fatal(err)
+	}
+
+	for i, v := range got {
+		if v != i*2 {
+			t.Fatalf("#%d: got %v, want %v", i, v, i*2)
+		}
+	}
+	if len(got) != 20 {
+		t.Fatalf("got %v, want %v", len(got), 20)
+	}
+}
+
+func TestParallelMapChanError(t *testing.T) {
+	errFoo := errors.New("foo")
+
+	in := make(chan int)
+	go func() {
+		defer close(in)
+		for i := 0; i < 20; i++ {
+			select {
+			case in <- i:
+			case <-time.After(time.Second):
+				return
+			}
+		}
+	}()
+
+	out, errCh := ParallelMapChan(context.Background(), in, 2, func(ctx context.Context, v int) (int, error) {
+		if v == 5 {
+			return 0, errFoo
+		}
+		return v, nil
+	})
+
+	for v := range out {
+		if v >= 5 {
+			t.Fatalf("got %v after error", v)
+		}
+	}
+	if err := errCh.Get(); !errors.Is(err, errFoo) {
+		t.Fatalf("got %v, want %v", err, errFoo)
+	}
+}
@@ -0,0 +1,207 @@
+package synx
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// Carrier holds propagated values, like http.Header.
+type Carrier interface {
+	Get(key string) string
+	Set(key, value string)
+}
+
+// MapCarrier is a Carrier based on map[string]string.
+type MapCarrier map[string]string
+
+// Get implements Carrier.
+func (m MapCarrier) Get(key string) string { return m[key] }
+
+// Set implements Carrier.
+func (m MapCarrier) Set(key, value string) { m[key] = value }
+
+// Codec encodes and decodes values for propagation.
+type Codec[T any] struct {
+	Encode func(T) (string, error)
+	Decode func(string) (T, error)
+}
+
+// StringCodec returns a Codec for strings which keeps them as is.
+func StringCodec() Codec[string] {
+	return Codec[string]{
+		Encode: func(s string) (string, error) { return s, nil },
+		Decode: func(s string) (string, error) { return s, nil },
+	}
+}
+
+// Propagator injects context values into a Carrier and extracts them back,
+// so they can be passed across process boundaries.
+//
+// Values and deadline must be registered before the first use of Propagator.
+type Propagator struct {
+	fields         []propagatedField
+	deadlineHeader string
+}
+
+type propagatedField struct {
+	header  string
+	inject  func(ctx context.Context) (string, bool, error)
+	extract func(ctx context.Context, s string) (context.Context, error)
+}
+
+// NewPropagator returns a new Propagator.
+func NewPropagator() *Propagator {
+	return &Propagator{}
+}
+
+// PropagateDeadline enables propagation of the context deadline under header.
+// Deadline is passed as a remaining time in milliseconds, so clocks do not need to be in sync.
+func (p *Propagator) PropagateDeadline(header string) {
+	p.deadlineHeader = header
+}
+
+// PropagateKey registers a Key to be propagated under header.
+func PropagateKey[T any](p *Propagator, header string, key *Key[T], codec Codec[T]) {
+	p.fields = append(p.fields, propagatedField{
+		header: header,
+		inject: func(ctx context.Context) (string, bool, error) {
+			v, ok := key.Get(ctx)
+			if !ok {
+				return "", false, nil
+			}
+			s, err := codec.Encode(v)
+			return s, true, err
+		},
+		extract: func(ctx context.Context, s string) (context.Context, error) {
+			v, err := codec.Decode(s)
+			if err != nil {
+				return ctx, err
+			}
+			return key.With(ctx, v), nil
+		},
+	})
+}
+
+// PropagateValue registers a value of type T (see WithValue and GetValue) to be propagated under header.
+func PropagateValue[T any](p *Propagator, header string, codec Codec[T]) {
+	p.fields = append(p.fields, propagatedField{
+		header: header,
+		inject: func(ctx context.Context) (string, bool, error) {
+			v, ok := ctx.Value(ctxKey[T]{}).(T)
+			if !ok {
+				return "", false, nil
+			}
+			s, err := codec.Encode(v)
+			return s, true, err
+		},
+		extract: func(ctx context.Context, s string) (context.Context, error) {
+			v, err := codec.Decode(s)
+			if err != nil {
+				return ctx, err
+			}
+			return WithValue(ctx, v), nil
+		},
+	})
+}
+
+// Inject registered values and deadline from ctx into carrier.
+func (p *Propagator) Inject(ctx context.Context, carrier Carrier) error {
+	for _, f := range p.fields {
+		s, ok, err := f.inject(ctx)
+		if err != nil {
+			return fmt.Errorf("synx: cannot encode %q: %w", f.header, err)
+		}
+		if ok {
+			carrier.Set(f.header, s)
+		}
+	}
+
+	if p.deadlineHeader != "" {
+		if deadline, ok := ctx.Deadline(); ok {
+			remaining := time.Until(deadline).Milliseconds()
+			if remaining < 0 {
+				remaining = 0
+			}
+			carrier.Set(p.deadlineHeader, strconv.FormatInt(remaining, 10))
+		}
+	}
+	return nil
+}
+
+const maxDeadlineMillis = int64(math.MaxInt64 / time.Millisecond)
+
+// Extract registered values and deadline from carrier into a new context based on ctx.
+// Cancel must be called to release resources when the context is not needed anymore.
+func (p *Propagator) Extract(ctx context.Context, carrier Carrier) (_ context.Context, cancel context.CancelFunc, err error) {
+	for _, f := range p.fields {
+		s := carrier.Get(f.header)
+		if s == "" {
+			continue
+		}
+		if ctx, err = f.extract(ctx, s); err != nil {
+			return nil, nil, fmt.Errorf("synx: cannot decode %q: %w", f.header, err)
+		}
+	}
+
+	if p.deadlineHeader != "" {
+		if s := carrier.Get(p.deadlineHeader); s != "" {
+			remaining, err := strconv.ParseInt(s, 10, 64)
+			if err != nil {
+				return nil, nil, fmt.Errorf("synx: cannot decode %q: %w", p.deadlineHeader, err)
+			}
+			// clamp to avoid overflow, such a deadline is effectively infinite anyway
+			switch {
+			case remaining > maxDeadlineMillis:
+				remaining = maxDeadlineMillis
+			case remaining < 0:
+				remaining = 0
+			}
+			ctx, cancel := context.WithTimeout(ctx, time.Duration(remaining)*time.Millisecond)
+			return ctx, cancel, nil
+		}
+	}
+	return ctx, func() {}, nil
+}
+
+// RoundTripper returns an http.RoundTripper which injects context values
+// of the request into its headers. If next is nil, http.DefaultTransport is used.
+func (p *Propagator) RoundTripper(next http.RoundTripper) http.RoundTripper {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	return roundTripperFn(func(req *http.Request) (*http.Response, error) {
+		req = req.Clone(req.Context())
+		if err := p.Inject(req.Context(), req.Header); err != nil {
+			if req.Body != nil {
+				req.Body.Close()
+			}
+			return nil, err
+		}
+		return next.RoundTrip(req)
+	})
+}
+
+type roundTripperFn func(req *http.Request) (*http.Response, error)
+
+func (fn roundTripperFn) RoundTrip(req *http.Request) (*http.Response, error) {
+	return fn(req)
+}
+
+// Middleware returns an http.Handler which extracts values from request headers
+// into the request context. Responds with 400 Bad Request if a value cannot be decoded.
+func (p *Propagator) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx, cancel, err := p.Extract(r.Context(), r.Header)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		defer cancel()
+
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
@@ -0,0 +1,168 @@
+package synx
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+)
+
+type TenantID int
+
+var tenantIDCodec = Codec[TenantID]{
+	Encode: func(v TenantID) (string, error) { return strconv.Itoa(int(v)), nil },
+	Decode: func(s string) (TenantID, error) {
+		v, err := strconv.Atoi(s)
+		return TenantID(v), err
+	},
+}
+
+func TestPropagator(t *testing.T) {
+	requestID := NewKey[string]("request-id")
+
+	p := NewPropagator()
+	PropagateKey(p, "X-Request-Id", requestID, StringCodec())
+	PropagateValue(p, "X-Tenant-Id", tenantIDCodec)
+	p.PropagateDeadline("X-Timeout-Ms")
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	ctx = requestID.With(ctx, "req-1")
+	ctx = WithValue(ctx, TenantID(42))
+
+	carrier := MapCarrier{}
+	if err := p.Inject(ctx, carrier); err != nil {
+		t.Fatal(err)
+	}
+	if got := carrier.Get("X-Request-Id"); got != "req-1" {
+		t.Fatalf("got %v, want %v", got, "req-1")
+	}
+
+	ctx, cancel, err := p.Extract(context.Background(), carrier)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer cancel()
+
+	if got, _ := requestID.Get(ctx); got != "req-1" {
+		t.Fatalf("got %v, want %v", got, "req-1")
+	}
+	if got := GetValue[TenantID](ctx); got != 42 {
+		t.Fatalf("got %v, want %v", got, 42)
+	}
+
+	deadline, ok := ctx.Deadline()
+	if remaining := time.Until(deadline); !ok || remaining > time.Minute || remaining < 50*time.Second {
+		t.Fatalf("got %v, want about %v", remaining, time.Minute)
+	}
+}
+
+func TestPropagatorExtractError(t *testing.T) {
+	p := NewPropagator()
+	PropagateValue(p, "X-Tenant-Id", tenantIDCodec)
+
+	_, _, err := p.Extract(context.Background(), MapCarrier{"X-Tenant-Id": "foo"})
+
+	var numErr *strconv.NumError
+	if !errors.As(err, &numErr) {
+		t.Fatalf("got %v, want %T", err, numErr)
+	}
+}
+
+func TestPropagatorExtractDeadlineOverflow(t *testing.T) {
+	p := NewPropagator()
+	p.PropagateDeadline("X-Timeout-Ms")
+
+	ctx, cancel, err := p.Extract(context.Background(), MapCarrier{"X-Timeout-Ms": "9223372036854775807"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer cancel()
+
+	if err := ctx.Err(); err != nil {
+		t.Fatalf("got %v, want %v", err, nil)
+	}
+	if deadline, ok := ctx.Deadline(); !ok || time.Until(deadline) < 24*time.Hour {
+		t.Fatalf("got %v, want far in the future", deadline)
+	}
+}
+
+func TestPropagatorRoundTripperClosesBody(t *testing.T) {
+	errEncode := errors.New("encode")
+
+	p := NewPropagator()
+	PropagateValue(p, "X-Tenant-Id", Codec[TenantID]{
+		Encode: func(TenantID) (string, error) { return "", errEncode },
+	})
+
+	body := &closeRecorder{}
+	req := httptest.NewRequest(http.MethodPost, "http://example.com", body)
+	req = req.WithContext(WithValue(req.Context(), TenantID(42)))
+
+	_, err := p.RoundTripper(nil).RoundTrip(req)
+	if !errors.Is(err, errEncode) {
+		t.Fatalf("got %v, want %v", err, errEncode)
+	}
+	if !body.closed {
+		t.Fatal("body must be closed")
+	}
+}
+
+type closeRecorder struct {
+	closed bool
+}
+
+func (r *closeRecorder) Read([]byte) (int, error) { return 0, io.EOF }
+
+func (r *closeRecorder) Close() error {
+	r.closed = true
+	return nil
+}
+
+func TestPropagatorHTTP(t *testing.T) {
+	requestID := NewKey[string]("request-id")
+
+	p := NewPropagator()
+	PropagateKey(p, "X-Request-Id", requestID, StringCodec())
+	p.PropagateDeadline("X-Timeout-Ms")
+
+	srv := httptest.NewServer(p.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, ok := r.Context().Deadline(); !ok {
+			http.Error(w, "no deadline", http.StatusInternalServerError)
+			return
+		}
+		id, _ := requestID.Get(r.Context())
+		w.Header().Set("X-Got-Id", id)
+	})))
+	defer srv.Close()
+
+	client := &http.Client{Transport: p.RoundTripper(nil)}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(requestID.With(ctx, "req-1"), http.MethodGet, srv.URL, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("got %v, want %v", resp.StatusCode, http.StatusOK)
+	}
+	if got := resp.Header.Get("X-Got-Id"); got != "req-1" {
+		t.Fatalf("got %v, want %v", got, "req-1")
+	}
+	if got := req.Header.Get("X-Request-Id"); got != "" {
+		t.Fatal("original request must not be modified")
+	}
+}
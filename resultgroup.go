@@ -0,0 +1,103 @@
+package synx
+
+import (
+	"context"
+	"sync"
+)
+
+// ResultGroup is a ContextGroup which collects results of the functions.
+type ResultGroup[T any] struct {
+	cg          *ContextGroup
+	mu          sync.Mutex
+	results     []groupResult[T]
+	keepErrored bool
+}
+
+type groupResult[T any] struct {
+	value   T
+	errored bool
+	done    bool
+}
+
+// NewResultGroup returns new ResultGroup.
+func NewResultGroup[T any](parent context.Context) *ResultGroup[T] {
+	return &ResultGroup[T]{
+		cg: NewContextGroup(parent),
+	}
+}
+
+// SetLimit limits the number of active goroutines in this group to at most n.
+// See ContextGroup.SetLimit.
+func (rg *ResultGroup[T]) SetLimit(n int) {
+	rg.cg.SetLimit(n)
+}
+
+// SetCollectAll switches the group between fail-fast (default) and collect-all modes.
+// See ContextGroup.SetCollectAll.
+func (rg *ResultGroup[T]) SetCollectAll(collectAll bool) {
+	rg.cg.SetCollectAll(collectAll)
+}
+
+// SetKeepErrored sets whether results of the functions which returned an error
+// are returned by Wait. Default is false, so such results are replaced with zero values.
+//
+// The option must not be modified while any goroutines in the group are active.
+func (rg *ResultGroup[T]) SetKeepErrored(keep bool) {
+	rg.keepErrored = keep
+}
+
+// Go calls the given function in a new goroutine.
+// See ContextGroup.Go.
+func (rg *ResultGroup[T]) Go(f func(context.Context) (T, error)) {
+	idx := rg.reserve()
+
+	rg.cg.Go(func(ctx context.Context) error {
+		value, err := f(ctx)
+		rg.store(idx, value, err)
+		return err
+	})
+}
+
+func (rg *ResultGroup[T]) reserve() int {
+	rg.mu.Lock()
+	defer rg.mu.Unlock()
+
+	rg.results = append(rg.results, groupResult[T]{})
+	return len(rg.results) - 1
+}
+
+func (rg *ResultGroup[T]) store(idx int, value T, err error) {
+	rg.mu.Lock()
+	defer rg.mu.Unlock()
+
+	rg.results[idx] = groupResult[T]{
+		value:   value,
+		errored: err != nil,
+		done:    true,
+	}
+}
+
+// Cancel cancels all goroutines in the group.
+func (rg *ResultGroup[T]) Cancel() {
+	rg.cg.Cancel()
+}
+
+// Wait blocks until all function calls have returned.
+// Returns an error like ContextGroup.WaitErr does and a result per Go call,
+// so values[i] is a result of the i-th Go call.
+// Results of functions which were not called, panicked or returned an error
+// (unless SetKeepErrored is set) are zero values.
+func (rg *ResultGroup[T]) Wait() ([]T, error) {
+	err := rg.cg.WaitErr()
+
+	rg.mu.Lock()
+	defer rg.mu.Unlock()
+
+	values := make([]T, len(rg.results))
+	for i, res := range rg.results {
+		if res.done && (!res.errored || rg.keepErrored) {
+			values[i] = res.value
+		}
+	}
+	return values, err
+}
@@ -0,0 +1,62 @@
+package synx
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestResultGroup(t *testing.T) {
+	rg := NewResultGroup[int](context.Background())
+	rg.SetLimit(2)
+
+	for i := 0; i < 10; i++ {
+		i := i
+		rg.Go(func(ctx context.Context) (int, error) {
+			time.Sleep(time.Duration(10-i) * time.Millisecond)
+			return i * i, nil
+		})
+	}
+
+	values, err := rg.Wait()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []int{0, 1, 4, 9, 16, 25, 36, 49, 64, 81}
+	if !reflect.DeepEqual(values, want) {
+		t.Fatalf("got %v, want %v", values, want)
+	}
+}
+
+func TestResultGroupErrored(t *testing.T) {
+	errFoo := errors.New("foo")
+
+	testCases := []struct {
+		keepErrored bool
+		want        []int
+	}{
+		{false, []int{1, 0, 3}},
+		{true, []int{1, -1, 3}},
+	}
+
+	for i, tc := range testCases {
+		rg := NewResultGroup[int](context.Background())
+		rg.SetCollectAll(true)
+		rg.SetKeepErrored(tc.keepErrored)
+
+		rg.Go(func(ctx context.Context) (int, error) { return 1, nil })
+		rg.Go(func(ctx context.Context) (int, error) { return -1, errFoo })
+		rg.Go(func(ctx context.Context) (int, error) { return 3, nil })
+
+		values, err := rg.Wait()
+		if !errors.Is(err, errFoo) {
+			t.Fatalf("#%d: got %v, want %v", i+1, err, errFoo)
+		}
+		if !reflect.DeepEqual(values, tc.want) {
+			t.Fatalf("#%d: got %v, want %v", i+1, values, tc.want)
+		}
+	}
+}
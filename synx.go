@@ -15,23 +15,46 @@ func BlockForever() {
 
 // Async executes fn in a goroutine.
 // Returned channel is closed when goroutine completes.
-func Async(fn func()) <-chan struct{} {
-	ch := make(chan struct{})
+// Panic in fn is recovered: the *PanicError is sent to the channel before it is closed,
+// so the first receive gets it (receives get nil if fn did not panic).
+func Async(fn func()) <-chan *PanicError {
+	ch := make(chan *PanicError, 1)
 	go func() {
 		defer close(ch)
-		fn()
+		if perr := catchPanic(fn); perr != nil {
+			ch <- perr
+		}
 	}()
 	return ch
 }
 
 // Wait for a function to finish.
+// If fn panics, the panic is re-panicked as *PanicError on the calling goroutine.
+// If ctx is done first, Wait returns ctx error and a later panic in fn
+// is re-panicked in the goroutine running fn.
 func Wait(ctx context.Context, fn func()) error {
-	ch := Async(fn)
+	done := make(chan *PanicError)
+	abandoned := make(chan struct{})
+
+	go func() {
+		perr := catchPanic(fn)
+		select {
+		case done <- perr:
+		case <-abandoned:
+			if perr != nil {
+				panic(perr)
+			}
+		}
+	}()
 
 	select {
 	case <-ctx.Done():
+		close(abandoned)
 		return ctx.Err()
-	case <-ch:
+	case perr := <-done:
+		if perr != nil {
+			panic(perr)
+		}
 		return nil
 	}
 }
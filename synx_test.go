@@ -42,3 +42,45 @@ func TestWaitFail(t *testing.T) {
 		t.Fatal(err)
 	}
 }
+
+func TestWaitPanic(t *testing.T) {
+	defer func() {
+		perr, ok := recover().(*PanicError)
+		if !ok {
+			t.Fatalf("got %T, want %T", perr, perr)
+		}
+		if perr.Value != "oops" {
+			t.Fatalf("got %v, want %v", perr.Value, "oops")
+		}
+	}()
+
+	_ = Wait(context.Background(), func() {
+		panic("oops")
+	})
+	t.Fatal("must panic")
+}
+
+func TestAsync(t *testing.T) {
+	if perr := <-Async(func() {}); perr != nil {
+		t.Fatal(perr)
+	}
+
+	done := Async(func() {
+		panic("oops")
+	})
+
+	select {
+	case perr := <-done:
+		if perr == nil {
+			t.Fatal("must not be nil")
+		}
+		if perr.Value != "oops" {
+			t.Fatalf("got %v, want %v", perr.Value, "oops")
+		}
+	case <-time.After(testDelay):
+		t.Fatal("timeout")
+	}
+	if _, ok := <-done; ok {
+		t.Fatal("must be closed")
+	}
+}
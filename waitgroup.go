@@ -8,6 +8,9 @@ import (
 type WaitGroup struct {
 	wg     sync.WaitGroup
 	doneCh chan struct{}
+
+	panicOnce sync.Once
+	panicErr  *PanicError
 }
 
 // NewWaitGroup returns a new WaitGroup.
@@ -18,11 +21,17 @@ func NewWaitGroup() *WaitGroup {
 }
 
 // Go run the given fn guarded with a wait group.
+// Panic in fn is recovered and re-panicked by Wait as *PanicError.
 func (wg *WaitGroup) Go(fn func()) {
 	wg.Add(1)
 	go func() {
 		defer wg.Done()
-		fn()
+
+		if perr := catchPanic(fn); perr != nil {
+			wg.panicOnce.Do(func() {
+				wg.panicErr = perr
+			})
+		}
 	}()
 }
 
@@ -37,9 +46,15 @@ func (wg *WaitGroup) Done() {
 }
 
 // Wait has same behaviour as sync.WaitGroup.
+// If any of the functions started by Go has panicked,
+// the first panic is re-panicked as *PanicError.
 func (wg *WaitGroup) Wait() {
 	wg.wg.Wait()
 	close(wg.doneCh)
+
+	if wg.panicErr != nil {
+		panic(wg.panicErr)
+	}
 }
 
 // DoneChan returns a channel that will be closed on completion.
@@ -0,0 +1,35 @@
+package synx
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestWaitGroupPanic(t *testing.T) {
+	errFoo := errors.New("foo")
+
+	wg := NewWaitGroup()
+	wg.Go(func() {})
+	wg.Go(func() {
+		panic(errFoo)
+	})
+
+	defer func() {
+		perr, ok := recover().(*PanicError)
+		if !ok {
+			t.Fatalf("got %T, want %T", perr, perr)
+		}
+		if !errors.Is(perr, errFoo) {
+			t.Fatalf("got %v, want %v", perr.Value, errFoo)
+		}
+
+		select {
+		case <-wg.DoneChan():
+		default:
+			t.Fatal("must be done")
+		}
+	}()
+
+	wg.Wait()
+	t.Fatal("must panic")
+}
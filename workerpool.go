@@ -1,48 +1,575 @@
 package synx
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
 	"time"
 )
 
+var (
+	// ErrWorkerPoolClosed is returned when a task is submitted to a closed WorkerPool.
+	ErrWorkerPoolClosed = errors.New("synx: worker pool is closed")
+
+	// ErrWorkerPoolFull is returned when a task is rejected because all workers are busy and the queue is full.
+	ErrWorkerPoolFull = errors.New("synx: worker pool is full")
+)
+
+// WorkerPool runs tasks on a limited number of goroutines.
+// Workers are started on demand and stop after being idle for lifetime.
 type WorkerPool struct {
-	lifetime  time.Duration
-	semaphore chan struct{}
-	taskQueue chan func()
+	cfg        WorkerPoolConfig
+	maxWorkers int
+
+	mu      sync.Mutex
+	queue   taskQueue
+	workers int // started workers
+	idle    int // workers waiting for a task
+	running int // tasks in progress
+	closed  bool
+
+	completed    int64
+	totalTime    time.Duration
+	maxQueueWait time.Duration
+	windowWait   time.Duration // max queue wait since the last autoscale sample
+
+	taskAdded  chan struct{} // closed and replaced when a task is queued
+	slotFreed  chan struct{} // closed and replaced when a task can be accepted
+	quit       chan struct{} // closed on shutdown
+	workersWG  sync.WaitGroup
+	workersEnd chan struct{} // closed when all workers have exited after shutdown
 }
 
+// WorkerPoolConfig represents WorkerPool config.
+type WorkerPoolConfig struct {
+	// MaxWorkers is a maximal number of workers. Must be greater than 0.
+	// Can be changed later via WorkerPool.Resize.
+	MaxWorkers int
+
+	// MinWorkers is a number of workers started on creation and kept alive regardless of Lifetime.
+	// Must not be greater than MaxWorkers. Default is 0.
+	MinWorkers int
+
+	// Lifetime is how long an idle worker is alive.
+	// Default is 0 which is treated as 1 minute.
+	Lifetime time.Duration
+
+	// QueueSize is how many tasks can wait for a worker when all workers are busy.
+	// Default is 0 which means that a task is accepted only by an idle or a new worker.
+	QueueSize int
+
+	// RejectWhenFull set to true makes DoContext return ErrWorkerPoolFull
+	// instead of waiting when all workers are busy and the queue is full.
+	// Default is false.
+	RejectWhenFull bool
+
+	// OnPanic is called with a recovered panic value and a stack trace when a task panics.
+	// The worker survives and continues to run tasks.
+	// Default is nil, the panic is re-panicked as *PanicError and crashes the process.
+	OnPanic func(value any, stack []byte)
+
+	// OnTaskStart is called on a worker before a task starts with the time it has waited in the queue.
+	OnTaskStart func(queueWait time.Duration)
+
+	// OnTaskFinish is called on a worker after a task finishes with the task duration.
+	OnTaskFinish func(duration time.Duration)
+
+	_ struct{} // enforce explicit field names.
+}
+
+// Validate the config.
+func (cfg *WorkerPoolConfig) Validate() error {
+	if cfg == nil {
+		return errors.New("WorkerPoolConfig cannot be nil")
+	}
+	if cfg.MaxWorkers < 1 {
+		return fmt.Errorf("MaxWorkers must be greater than 0, got: %v", cfg.MaxWorkers)
+	}
+	if cfg.MinWorkers < 0 || cfg.MinWorkers > cfg.MaxWorkers {
+		return fmt.Errorf("MinWorkers must be between 0 and MaxWorkers, got: %v", cfg.MinWorkers)
+	}
+	if cfg.Lifetime < 0 {
+		return fmt.Errorf("Lifetime cannot be negative, got: %v", cfg.Lifetime)
+	}
+	if cfg.Lifetime == 0 {
+		cfg.Lifetime = time.Minute
+	}
+	if cfg.QueueSize < 0 {
+		return fmt.Errorf("QueueSize cannot be negative, got: %v", cfg.QueueSize)
+	}
+	return nil
+}
+
+// NewWorkerPool returns a new WorkerPool.
 func NewWorkerPool(maxWorkers int, lifetime time.Duration) *WorkerPool {
-	return &WorkerPool{
-		lifetime:  lifetime,
-		semaphore: make(chan struct{}, maxWorkers),
-		taskQueue: make(chan func()),
+	switch {
+	case maxWorkers < 1:
+		panic("synx: maxWorkers must be greater than 0")
+	case lifetime <= 0:
+		panic("synx: lifetime must be greater than 0")
+	}
+
+	wp, err := NewWorkerPoolWithConfig(&WorkerPoolConfig{
+		MaxWorkers: maxWorkers,
+		Lifetime:   lifetime,
+	})
+	if err != nil {
+		panic("synx: " + err.Error())
+	}
+	return wp
+}
+
+// NewWorkerPoolWithConfig returns a new WorkerPool.
+func NewWorkerPoolWithConfig(cfg *WorkerPoolConfig) (*WorkerPool, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+
+	wp := &WorkerPool{
+		cfg:        *cfg,
+		maxWorkers: cfg.MaxWorkers,
+		taskAdded:  make(chan struct{}),
+		slotFreed:  make(chan struct{}),
+		quit:       make(chan struct{}),
+		workersEnd: make(chan struct{}),
+	}
+
+	wp.workers = cfg.MinWorkers
+	wp.workersWG.Add(cfg.MinWorkers)
+	for i := 0; i < cfg.MinWorkers; i++ {
+		go wp.startWorker(queuedTask{})
+	}
+	return wp, nil
+}
+
+// Resize changes the maximal number of workers. n must be greater than 0.
+// n less than WorkerPoolConfig.MinWorkers is raised to MinWorkers, so warm workers are kept.
+// Workers above the new limit exit after finishing their current tasks.
+func (wp *WorkerPool) Resize(n int) {
+	if n < 1 {
+		panic("synx: n must be greater than 0")
+	}
+
+	wp.mu.Lock()
+	defer wp.mu.Unlock()
+
+	if n < wp.cfg.MinWorkers {
+		n = wp.cfg.MinWorkers
+	}
+	wp.maxWorkers = n
+
+	// restore warm workers if some of them are gone
+	for !wp.closed && wp.workers < wp.cfg.MinWorkers {
+		wp.workers++
+		wp.workersWG.Add(1)
+		go wp.startWorker(queuedTask{})
 	}
+
+	// start workers for the queued tasks which are not going to be taken by idle workers
+	for wp.workers < wp.maxWorkers && wp.queue.len() > wp.idle {
+		qt := wp.queue.pop()
+
+		wp.workers++
+		wp.running++
+		wp.workersWG.Add(1)
+		go wp.startWorker(qt)
+	}
+
+	wp.broadcast(&wp.slotFreed)
+	wp.broadcast(&wp.taskAdded) // wake up idle workers to exit if needed
 }
 
+// Do runs the task on an idle worker or on a new one if there are less than maxWorkers.
+// Otherwise the task is queued if the queue is not full or Do blocks until it can be accepted.
+// Panics if the pool is already closed. If the pool is closed while Do is blocked,
+// the task is dropped and Do returns. Use DoContext to get ErrWorkerPoolClosed instead.
 func (wp *WorkerPool) Do(task func()) {
-	select {
-	case wp.taskQueue <- task:
-		// submitted, everything is ok
+	wp.DoWith(TaskOptions{}, task)
+}
+
+// TaskOptions configures scheduling of a task. See DoWith.
+type TaskOptions struct {
+	// Priority of the task. Queued tasks with higher priority run first.
+	// Default is 0.
+	Priority int
+
+	// Key of the task (ex: tenant ID). Queued tasks with the same priority
+	// run round-robin by key, so one key cannot monopolize workers.
+	// Tasks with the same key run in FIFO order. Default is "".
+	Key string
+}
+
+// DoWith is like Do but with scheduling options.
+// Options matter only for the queued tasks, see WorkerPoolConfig.QueueSize.
+func (wp *WorkerPool) DoWith(opts TaskOptions, task func()) {
+	wp.mu.Lock()
+	closed := wp.closed
+	wp.mu.Unlock()
+
+	if closed {
+		panic("synx: Do on closed WorkerPool")
+	}
+	// the only possible error is ErrWorkerPoolClosed, the task is dropped then
+	_ = wp.submit(nil, opts, task, true)
+}
+
+// DoPriority is like Do but with a priority. See TaskOptions.Priority.
+func (wp *WorkerPool) DoPriority(priority int, task func()) {
+	wp.DoWith(TaskOptions{Priority: priority}, task)
+}
+
+// DoFair is like Do but with a fair queuing key. See TaskOptions.Key.
+func (wp *WorkerPool) DoFair(key string, task func()) {
+	wp.DoWith(TaskOptions{Key: key}, task)
+}
+
+// DoContext is like Do but returns ctx error if the task cannot be accepted before ctx is done.
+// Returns ErrWorkerPoolFull without waiting if the pool is configured with RejectWhenFull.
+// Returns ErrWorkerPoolClosed if the pool is closed.
+func (wp *WorkerPool) DoContext(ctx context.Context, task func()) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return wp.submit(ctx, TaskOptions{}, task, !wp.cfg.RejectWhenFull)
+}
+
+// TryDo is like Do but never blocks. Returns false if the task cannot be accepted right now
+// or the pool is closed.
+func (wp *WorkerPool) TryDo(task func()) bool {
+	return wp.submit(nil, TaskOptions{}, task, false) == nil
+}
+
+// submit the task, waits if wait is true while ctx (if not nil) is not done.
+func (wp *WorkerPool) submit(ctx context.Context, opts TaskOptions, task func(), wait bool) error {
+	var ctxDone <-chan struct{}
+	if ctx != nil {
+		ctxDone = ctx.Done()
+	}
+
+	for {
+		wp.mu.Lock()
+		if wp.closed {
+			wp.mu.Unlock()
+			return ErrWorkerPoolClosed
+		}
+
+		qt := queuedTask{
+			task:     task,
+			queuedAt: time.Now(),
+			priority: opts.Priority,
+			key:      opts.Key,
+		}
+
+		switch {
+		case wp.queue.len() < wp.idle:
+			wp.queue.push(qt)
+			wp.broadcast(&wp.taskAdded)
+			wp.mu.Unlock()
+			return nil
+
+		case wp.workers < wp.maxWorkers:
+			wp.workers++
+			wp.running++
+			wp.workersWG.Add(1)
+			wp.mu.Unlock()
+
+			go wp.startWorker(qt)
+			return nil
+
+		case wp.queue.len() < wp.idle+wp.cfg.QueueSize:
+			wp.queue.push(qt)
+			wp.mu.Unlock()
+			return nil
+		}
+
+		slotFreed := wp.slotFreed
+		wp.mu.Unlock()
+
+		if !wait {
+			return ErrWorkerPoolFull
+		}
+
+		select {
+		case <-slotFreed:
+		case <-wp.quit:
+		case <-ctxDone:
+			return ctx.Err()
+		}
+	}
+}
+
+// broadcast wakes up all waiters of ch. Must be called with mu held.
+func (wp *WorkerPool) broadcast(ch *chan struct{}) {
+	close(*ch)
+	*ch = make(chan struct{})
+}
+
+type queuedTask struct {
+	task     func()
+	queuedAt time.Time
+	priority int
+	key      string
+}
+
+func (wp *WorkerPool) startWorker(qt queuedTask) {
+	defer wp.workersWG.Done()
+
+	timer := time.NewTimer(wp.cfg.Lifetime)
+	defer timer.Stop()
+
+	for {
+		ran := qt.task != nil // idle workers are started without a task
+
+		var wait, duration time.Duration
+		if ran {
+			wait, duration = wp.runTask(qt)
+		}
+
+		var ok bool
+		qt, ok = wp.nextTask(timer, ran, wait, duration)
+		if !ok {
+			return
+		}
+	}
+}
+
+func (wp *WorkerPool) runTask(qt queuedTask) (wait, duration time.Duration) {
+	start := time.Now()
+	wait = start.Sub(qt.queuedAt)
+	if wp.cfg.OnTaskStart != nil {
+		wp.cfg.OnTaskStart(wait)
+	}
+
+	perr := catchPanic(qt.task)
+
+	duration = time.Since(start)
+	if wp.cfg.OnTaskFinish != nil {
+		wp.cfg.OnTaskFinish(duration)
+	}
+
+	if perr != nil {
+		if wp.cfg.OnPanic == nil {
+			panic(perr)
+		}
+		wp.cfg.OnPanic(perr.Value, perr.Stack)
+	}
+	return wait, duration
+}
+
+// nextTask records stats of the finished task and waits for a new task.
+// Returns false when the worker must exit.
+func (wp *WorkerPool) nextTask(timer *time.Timer, ran bool, wait, duration time.Duration) (queuedTask, bool) {
+	wp.mu.Lock()
+	defer wp.mu.Unlock()
+
+	if ran {
+		wp.running--
+		wp.completed++
+		wp.totalTime += duration
+		if wait > wp.maxQueueWait {
+			wp.maxQueueWait = wait
+		}
+		if wait > wp.windowWait {
+			wp.windowWait = wait
+		}
+	}
+	resetTimer(timer, wp.cfg.Lifetime)
+
+	for {
+		if wp.workers > wp.maxWorkers {
+			break
+		}
+		if wp.queue.len() > 0 {
+			qt := wp.queue.pop()
+			wp.running++
+			wp.broadcast(&wp.slotFreed)
+			return qt, true
+		}
+		if wp.closed {
+			break
+		}
+
+		wp.idle++
+		wp.broadcast(&wp.slotFreed)
+		taskAdded := wp.taskAdded
+		wp.mu.Unlock()
+
+		expired := false
+		select {
+		case <-taskAdded:
+		case <-wp.quit:
+		case <-timer.C:
+			expired = true
+		}
+
+		wp.mu.Lock()
+		wp.idle--
+		if expired && wp.queue.len() == 0 {
+			if wp.workers > wp.cfg.MinWorkers {
+				break
+			}
+			resetTimer(timer, wp.cfg.Lifetime)
+		}
+	}
+
+	wp.workers--
+	wp.broadcast(&wp.slotFreed)
+	return queuedTask{}, false
+}
 
-	case wp.semaphore <- struct{}{}:
-		go wp.startWorker(task)
+func resetTimer(timer *time.Timer, d time.Duration) {
+	if !timer.Stop() {
+		select {
+		case <-timer.C:
+		default:
+		}
 	}
+	timer.Reset(d)
 }
 
-func (wp *WorkerPool) startWorker(task func()) {
-	defer func() { <-wp.semaphore }()
+// WorkerPoolStats is a snapshot of WorkerPool state and counters.
+type WorkerPoolStats struct {
+	MaxWorkers     int           // current limit of workers
+	Workers        int           // started workers
+	IdleWorkers    int           // workers without a task
+	BusyWorkers    int           // workers running a task
+	QueuedTasks    int           // tasks waiting for a worker
+	CompletedTasks int64         // finished tasks (including panicked)
+	TotalTaskTime  time.Duration // sum of finished tasks durations
+	MaxQueueWait   time.Duration // maximal time a task has waited for a worker
+}
+
+// Stats returns a snapshot of the pool state and counters.
+func (wp *WorkerPool) Stats() WorkerPoolStats {
+	wp.mu.Lock()
+	defer wp.mu.Unlock()
+	return wp.statsLocked()
+}
+
+func (wp *WorkerPool) statsLocked() WorkerPoolStats {
+	return WorkerPoolStats{
+		MaxWorkers:     wp.maxWorkers,
+		Workers:        wp.workers,
+		IdleWorkers:    wp.workers - wp.running,
+		BusyWorkers:    wp.running,
+		QueuedTasks:    wp.queue.len(),
+		CompletedTasks: wp.completed,
+		TotalTaskTime:  wp.totalTime,
+		MaxQueueWait:   wp.maxQueueWait,
+	}
+}
 
-	task() // do the given task
+// AutoscaleSample is passed to AutoscalePolicy. See WorkerPool.Autoscale.
+type AutoscaleSample struct {
+	Stats WorkerPoolStats
 
-	tick := time.NewTicker(wp.lifetime)
-	defer tick.Stop()
+	// QueueWait is the maximal time a task has waited for a worker since the previous sample.
+	// Includes the time the oldest queued task is waiting.
+	QueueWait time.Duration
+}
+
+// AutoscalePolicy returns a new maximal number of workers.
+type AutoscalePolicy func(sample AutoscaleSample) int
+
+// QueueWaitPolicy returns an AutoscalePolicy which doubles the number of workers
+// when the queue wait is above target and removes a worker when the queue wait is
+// below half of the target and some workers are idle.
+// The result is kept between minWorkers and maxWorkers.
+func QueueWaitPolicy(target time.Duration, minWorkers, maxWorkers int) AutoscalePolicy {
+	switch {
+	case minWorkers < 1:
+		panic("synx: minWorkers must be greater than 0")
+	case maxWorkers < minWorkers:
+		panic("synx: maxWorkers cannot be less than minWorkers")
+	}
+
+	return func(sample AutoscaleSample) int {
+		n := sample.Stats.MaxWorkers
+		switch {
+		case sample.QueueWait > target:
+			n *= 2
+		case sample.QueueWait < target/2 && sample.Stats.IdleWorkers > 0:
+			n--
+		}
+
+		switch {
+		case n < minWorkers:
+			return minWorkers
+		case n > maxWorkers:
+			return maxWorkers
+		default:
+			return n
+		}
+	}
+}
+
+// Autoscale resizes the pool every interval using the given policy.
+// Blocks till ctx is done or the pool is closed.
+func (wp *WorkerPool) Autoscale(ctx context.Context, interval time.Duration, policy AutoscalePolicy) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
 
 	for {
 		select {
-		case task := <-wp.taskQueue:
-			task()
-			tick.Reset(wp.lifetime)
-		case <-tick.C:
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		case <-wp.quit:
 			return
 		}
+
+		sample := wp.sample()
+		if n := policy(sample); n != sample.Stats.MaxWorkers {
+			wp.Resize(n)
+		}
+	}
+}
+
+func (wp *WorkerPool) sample() AutoscaleSample {
+	wp.mu.Lock()
+	defer wp.mu.Unlock()
+
+	wait := wp.windowWait
+	wp.windowWait = 0
+	if oldest, ok := wp.queue.oldest(); ok {
+		if since := time.Since(oldest); since > wait {
+			wait = since
+		}
+	}
+
+	return AutoscaleSample{
+		Stats:     wp.statsLocked(),
+		QueueWait: wait,
+	}
+}
+
+// Shutdown stops accepting new tasks and waits for the queued and in-flight tasks
+// till ctx is done. Returns the number of unfinished tasks and ctx error if ctx is done first.
+func (wp *WorkerPool) Shutdown(ctx context.Context) (remaining int, err error) {
+	wp.mu.Lock()
+	if !wp.closed {
+		wp.closed = true
+		close(wp.quit)
+
+		go func() {
+			wp.workersWG.Wait()
+			close(wp.workersEnd)
+		}()
 	}
+	wp.mu.Unlock()
+
+	select {
+	case <-wp.workersEnd:
+		return 0, nil
+	case <-ctx.Done():
+		wp.mu.Lock()
+		defer wp.mu.Unlock()
+		return wp.running + wp.queue.len(), ctx.Err()
+	}
+}
+
+// Close stops accepting new tasks and waits for all tasks and all workers to finish.
+func (wp *WorkerPool) Close() {
+	_, _ = wp.Shutdown(context.Background())
 }
@@ -0,0 +1,106 @@
+package synx
+
+import (
+	"sort"
+	"time"
+)
+
+// taskQueue is a priority queue of tasks. Tasks with the same priority
+// are taken round-robin by key and in FIFO order for the same key.
+type taskQueue struct {
+	levels     map[int]*fairQueue
+	priorities []int // sorted in descending order
+	size       int
+}
+
+func (q *taskQueue) len() int { return q.size }
+
+func (q *taskQueue) push(qt queuedTask) {
+	if q.levels == nil {
+		q.levels = map[int]*fairQueue{}
+	}
+
+	level, ok := q.levels[qt.priority]
+	if !ok {
+		level = &fairQueue{tasks: map[string][]queuedTask{}}
+		q.levels[qt.priority] = level
+
+		idx := sort.Search(len(q.priorities), func(i int) bool {
+			return q.priorities[i] < qt.priority
+		})
+		q.priorities = append(q.priorities, 0)
+		copy(q.priorities[idx+1:], q.priorities[idx:])
+		q.priorities[idx] = qt.priority
+	}
+
+	level.push(qt)
+	q.size++
+}
+
+// pop the next task. Queue must not be empty.
+func (q *taskQueue) pop() queuedTask {
+	priority := q.priorities[0]
+	level := q.levels[priority]
+
+	qt := level.pop()
+	q.size--
+
+	if level.len() == 0 {
+		delete(q.levels, priority)
+		q.priorities = q.priorities[1:]
+	}
+	return qt
+}
+
+// oldest returns the time of the longest waiting task.
+func (q *taskQueue) oldest() (oldest time.Time, ok bool) {
+	for _, level := range q.levels {
+		for _, tasks := range level.tasks {
+			if t := tasks[0].queuedAt; !ok || t.Before(oldest) {
+				oldest, ok = t, true
+			}
+		}
+	}
+	return oldest, ok
+}
+
+// fairQueue takes tasks round-robin by key.
+type fairQueue struct {
+	keys  []string // keys with tasks in order of arrival
+	next  int      // index in keys of the next key to take a task from
+	tasks map[string][]queuedTask
+	size  int
+}
+
+func (q *fairQueue) len() int { return q.size }
+
+func (q *fairQueue) push(qt queuedTask) {
+	tasks, ok := q.tasks[qt.key]
+	if !ok || len(tasks) == 0 {
+		q.keys = append(q.keys, qt.key)
+	}
+	q.tasks[qt.key] = append(tasks, qt)
+	q.size++
+}
+
+func (q *fairQueue) pop() queuedTask {
+	key := q.keys[q.next]
+	tasks := q.tasks[key]
+
+	qt := tasks[0]
+	tasks[0] = queuedTask{}
+	tasks = tasks[1:]
+	q.size--
+
+	if len(tasks) == 0 {
+		delete(q.tasks, key)
+		q.keys = append(q.keys[:q.next], q.keys[q.next+1:]...)
+	} else {
+		q.tasks[key] = tasks
+		q.next++
+	}
+	if q.next >= len(q.keys) {
+		q.next = 0
+	}
+	return qt
+}
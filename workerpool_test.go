@@ -1,7 +1,12 @@
 package synx
 
 import (
+	"context"
+	"errors"
+	"reflect"
 	"runtime"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 )
@@ -38,3 +43,469 @@ func TestWorkerPool(t *testing.T) {
 		}
 	}
 }
+
+func TestWorkerPoolShutdown(t *testing.T) {
+	wp := NewWorkerPool(2, time.Minute)
+
+	startG := runtime.NumGoroutine()
+	release := make(chan struct{})
+	var done int32
+
+	for i := 0; i < 2; i++ {
+		wp.Do(func() {
+			<-release
+			atomic.AddInt32(&done, 1)
+		})
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), testDelay)
+	defer cancel()
+
+	remaining, err := wp.Shutdown(ctx)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("got %v, want %v", err, context.DeadlineExceeded)
+	}
+	if remaining != 2 {
+		t.Fatalf("got %v, want %v", remaining, 2)
+	}
+
+	close(release)
+	wp.Close()
+
+	if n := atomic.LoadInt32(&done); n != 2 {
+		t.Fatalf("got %v, want %v", n, 2)
+	}
+	if n := runtime.NumGoroutine() - startG; n > 0 {
+		t.Fatalf("got %v goroutines, want %v", n, 0)
+	}
+
+	remaining, err = wp.Shutdown(context.Background())
+	if remaining != 0 || err != nil {
+		t.Fatalf("got (%v, %v), want (%v, %v)", remaining, err, 0, nil)
+	}
+}
+
+func TestWorkerPoolDoClosed(t *testing.T) {
+	wp := NewWorkerPool(1, time.Minute)
+	wp.Close()
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Fatal("must panic")
+		}
+	}()
+	wp.Do(func() {})
+}
+
+func TestWorkerPoolDoContext(t *testing.T) {
+	wp := NewWorkerPool(1, time.Minute)
+	defer wp.Close()
+
+	release := make(chan struct{})
+	wp.Do(func() { <-release })
+
+	ctx, cancel := context.WithTimeout(context.Background(), testDelay)
+	defer cancel()
+
+	if err := wp.DoContext(ctx, func() {}); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("got %v, want %v", err, context.DeadlineExceeded)
+	}
+	if wp.TryDo(func() {}) {
+		t.Fatal("must not be accepted")
+	}
+	close(release)
+
+	done := make(chan struct{})
+	if err := wp.DoContext(context.Background(), func() { close(done) }); err != nil {
+		t.Fatal(err)
+	}
+	waitFor(t, done)
+}
+
+func TestWorkerPoolQueue(t *testing.T) {
+	wp, err := NewWorkerPoolWithConfig(&WorkerPoolConfig{
+		MaxWorkers:     1,
+		QueueSize:      2,
+		RejectWhenFull: true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	release := make(chan struct{})
+	var done int32
+	task := func() {
+		<-release
+		atomic.AddInt32(&done, 1)
+	}
+
+	for i := 0; i < 3; i++ {
+		if err := wp.DoContext(context.Background(), task); err != nil {
+			t.Fatalf("#%d: %v", i, err)
+		}
+	}
+	if err := wp.DoContext(context.Background(), task); !errors.Is(err, ErrWorkerPoolFull) {
+		t.Fatalf("got %v, want %v", err, ErrWorkerPoolFull)
+	}
+	if wp.TryDo(task) {
+		t.Fatal("must not be accepted")
+	}
+
+	close(release)
+	wp.Close()
+
+	if n := atomic.LoadInt32(&done); n != 3 {
+		t.Fatalf("got %v, want %v", n, 3)
+	}
+	if err := wp.DoContext(context.Background(), task); !errors.Is(err, ErrWorkerPoolClosed) {
+		t.Fatalf("got %v, want %v", err, ErrWorkerPoolClosed)
+	}
+}
+
+func TestWorkerPoolConfigValidate(t *testing.T) {
+	if _, err := NewWorkerPoolWithConfig(&WorkerPoolConfig{}); err == nil {
+		t.Fatal("must fail")
+	}
+	if _, err := NewWorkerPoolWithConfig(&WorkerPoolConfig{MaxWorkers: 1, QueueSize: -1}); err == nil {
+		t.Fatal("must fail")
+	}
+}
+
+func TestWorkerPoolPanic(t *testing.T) {
+	var panics int32
+	var lastValue atomic.Value
+
+	wp, err := NewWorkerPoolWithConfig(&WorkerPoolConfig{
+		MaxWorkers: 1,
+		OnPanic: func(value any, stack []byte) {
+			if len(stack) == 0 {
+				t.Error("stack must be set")
+			}
+			lastValue.Store(value)
+			atomic.AddInt32(&panics, 1)
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var done int32
+	for i := 0; i < 5; i++ {
+		wp.Do(func() { panic("oops") })
+		wp.Do(func() { atomic.AddInt32(&done, 1) })
+	}
+	wp.Close()
+
+	if n := atomic.LoadInt32(&panics); n != 5 {
+		t.Fatalf("got %v, want %v", n, 5)
+	}
+	if n := atomic.LoadInt32(&done); n != 5 {
+		t.Fatalf("got %v, want %v", n, 5)
+	}
+	if v := lastValue.Load(); v != "oops" {
+		t.Fatalf("got %v, want %v", v, "oops")
+	}
+}
+
+func TestWorkerPoolStats(t *testing.T) {
+	var started, finished int32
+
+	wp, err := NewWorkerPoolWithConfig(&WorkerPoolConfig{
+		MaxWorkers:   2,
+		QueueSize:    2,
+		OnTaskStart:  func(time.Duration) { atomic.AddInt32(&started, 1) },
+		OnTaskFinish: func(time.Duration) { atomic.AddInt32(&finished, 1) },
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	release := make(chan struct{})
+	for i := 0; i < 4; i++ {
+		wp.Do(func() { <-release })
+	}
+
+	stats := wp.Stats()
+	want := WorkerPoolStats{
+		MaxWorkers:  2,
+		Workers:     2,
+		BusyWorkers: 2,
+		QueuedTasks: 2,
+	}
+	if stats != want {
+		t.Fatalf("got %+v, want %+v", stats, want)
+	}
+
+	time.Sleep(testDelay / 2)
+	close(release)
+	wp.Close()
+
+	stats = wp.Stats()
+	if stats.CompletedTasks != 4 {
+		t.Fatalf("got %v, want %v", stats.CompletedTasks, 4)
+	}
+	if stats.MaxQueueWait < testDelay/2 {
+		t.Fatalf("got %v, want at least %v", stats.MaxQueueWait, testDelay/2)
+	}
+	if stats.TotalTaskTime < testDelay {
+		t.Fatalf("got %v, want at least %v", stats.TotalTaskTime, testDelay)
+	}
+	if stats.Workers != 0 || stats.BusyWorkers != 0 {
+		t.Fatalf("got %+v, want no workers", stats)
+	}
+	if started != 4 || finished != 4 {
+		t.Fatalf("got (%v, %v), want (%v, %v)", started, finished, 4, 4)
+	}
+}
+
+func TestWorkerPoolMinWorkers(t *testing.T) {
+	wp, err := NewWorkerPoolWithConfig(&WorkerPoolConfig{
+		MaxWorkers: 4,
+		MinWorkers: 2,
+		Lifetime:   testDelay / 10,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if n := wp.Stats().Workers; n != 2 {
+		t.Fatalf("got %v, want %v", n, 2)
+	}
+
+	release := make(chan struct{})
+	for i := 0; i < 4; i++ {
+		wp.Do(func() { <-release })
+	}
+	if n := wp.Stats().Workers; n != 4 {
+		t.Fatalf("got %v, want %v", n, 4)
+	}
+	close(release)
+
+	time.Sleep(testDelay)
+
+	if n := wp.Stats().Workers; n != 2 {
+		t.Fatalf("got %v, want %v", n, 2)
+	}
+	wp.Close()
+}
+
+func TestWorkerPoolResize(t *testing.T) {
+	wp := NewWorkerPool(1, time.Minute)
+
+	release := make(chan struct{})
+	wp.Do(func() { <-release })
+
+	if wp.TryDo(func() {}) {
+		t.Fatal("must not be accepted")
+	}
+
+	wp.Resize(3)
+	wp.Do(func() { <-release })
+	wp.Do(func() { <-release })
+
+	if n := wp.Stats().Workers; n != 3 {
+		t.Fatalf("got %v, want %v", n, 3)
+	}
+
+	wp.Resize(1)
+	close(release)
+	time.Sleep(testDelay / 2)
+
+	if n := wp.Stats().Workers; n != 1 {
+		t.Fatalf("got %v, want %v", n, 1)
+	}
+	wp.Close()
+}
+
+func TestWorkerPoolResizeMinWorkers(t *testing.T) {
+	wp, err := NewWorkerPoolWithConfig(&WorkerPoolConfig{
+		MaxWorkers: 4,
+		MinWorkers: 2,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	wp.Resize(1)
+	time.Sleep(testDelay / 2)
+
+	stats := wp.Stats()
+	if stats.MaxWorkers != 2 || stats.Workers != 2 {
+		t.Fatalf("got (%v, %v), want (%v, %v)", stats.MaxWorkers, stats.Workers, 2, 2)
+	}
+
+	wp.Resize(4)
+	if n := wp.Stats().Workers; n != 2 {
+		t.Fatalf("got %v, want %v", n, 2)
+	}
+	wp.Close()
+}
+
+func TestQueueWaitPolicy(t *testing.T) {
+	policy := QueueWaitPolicy(100*time.Millisecond, 2, 10)
+
+	testCases := []struct {
+		sample AutoscaleSample
+		want   int
+	}{
+		{AutoscaleSample{Stats: WorkerPoolStats{MaxWorkers: 4}, QueueWait: time.Second}, 8},
+		{AutoscaleSample{Stats: WorkerPoolStats{MaxWorkers: 8}, QueueWait: time.Second}, 10},
+		{AutoscaleSample{Stats: WorkerPoolStats{MaxWorkers: 4}, QueueWait: 70 * time.Millisecond}, 4},
+		{AutoscaleSample{Stats: WorkerPoolStats{MaxWorkers: 4, IdleWorkers: 1}, QueueWait: 0}, 3},
+		{AutoscaleSample{Stats: WorkerPoolStats{MaxWorkers: 2, IdleWorkers: 2}, QueueWait: 0}, 2},
+	}
+
+	for i, tc := range testCases {
+		if got := policy(tc.sample); got != tc.want {
+			t.Fatalf("#%d: got %v, want %v", i+1, got, tc.want)
+		}
+	}
+}
+
+func TestWorkerPoolAutoscale(t *testing.T) {
+	wp, err := NewWorkerPoolWithConfig(&WorkerPoolConfig{
+		MaxWorkers: 1,
+		QueueSize:  10,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer wp.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go wp.Autoscale(ctx, testDelay/10, QueueWaitPolicy(testDelay/10, 1, 4))
+
+	release := make(chan struct{})
+	defer close(release)
+
+	for i := 0; i < 5; i++ {
+		wp.Do(func() { <-release })
+	}
+	time.Sleep(testDelay)
+
+	if n := wp.Stats().Workers; n != 4 {
+		t.Fatalf("got %v, want %v", n, 4)
+	}
+}
+
+func TestWorkerPoolCloseWhileDoBlocked(t *testing.T) {
+	wp, err := NewWorkerPoolWithConfig(&WorkerPoolConfig{MaxWorkers: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	release := make(chan struct{})
+	wp.Do(func() { <-release })
+
+	var called int32
+	recovered := make(chan any, 1)
+	go func() {
+		defer func() { recovered <- recover() }()
+		wp.Do(func() { atomic.AddInt32(&called, 1) })
+	}()
+
+	time.Sleep(testDelay / 2)
+	closed := make(chan struct{})
+	go func() {
+		wp.Close()
+		close(closed)
+	}()
+
+	select {
+	case r := <-recovered:
+		if r != nil {
+			t.Fatalf("got panic %v, want none", r)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("timeout")
+	}
+
+	close(release)
+	waitFor(t, closed)
+
+	if n := atomic.LoadInt32(&called); n != 0 {
+		t.Fatalf("got %v, want %v", n, 0)
+	}
+}
+
+func TestWorkerPoolPriority(t *testing.T) {
+	order := runScheduled(t, func(wp *WorkerPool, record func(string) func()) {
+		wp.DoPriority(0, record("low1"))
+		wp.DoPriority(5, record("high1"))
+		wp.DoPriority(1, record("mid"))
+		wp.DoPriority(0, record("low2"))
+		wp.DoPriority(5, record("high2"))
+		wp.DoPriority(-1, record("batch"))
+	})
+
+	want := []string{"high1", "high2", "mid", "low1", "low2", "batch"}
+	if !reflect.DeepEqual(order, want) {
+		t.Fatalf("got %v, want %v", order, want)
+	}
+}
+
+func TestWorkerPoolFair(t *testing.T) {
+	order := runScheduled(t, func(wp *WorkerPool, record func(string) func()) {
+		wp.DoFair("a", record("a1"))
+		wp.DoFair("a", record("a2"))
+		wp.DoFair("a", record("a3"))
+		wp.DoFair("b", record("b1"))
+		wp.DoFair("a", record("a4"))
+		wp.DoFair("c", record("c1"))
+		wp.DoFair("b", record("b2"))
+	})
+
+	want := []string{"a1", "b1", "c1", "a2", "b2", "a3", "a4"}
+	if !reflect.DeepEqual(order, want) {
+		t.Fatalf("got %v, want %v", order, want)
+	}
+}
+
+func TestWorkerPoolPriorityAndFair(t *testing.T) {
+	order := runScheduled(t, func(wp *WorkerPool, record func(string) func()) {
+		wp.DoWith(TaskOptions{Key: "a"}, record("a1"))
+		wp.DoWith(TaskOptions{Key: "a"}, record("a2"))
+		wp.DoWith(TaskOptions{Key: "b"}, record("b1"))
+		wp.DoWith(TaskOptions{Key: "a", Priority: 1}, record("a-high"))
+		wp.DoWith(TaskOptions{Key: "b", Priority: 1}, record("b-high"))
+	})
+
+	want := []string{"a-high", "b-high", "a1", "b1", "a2"}
+	if !reflect.DeepEqual(order, want) {
+		t.Fatalf("got %v, want %v", order, want)
+	}
+}
+
+// runScheduled queues tasks behind a blocked single worker
+// and returns the order in which they are executed.
+func runScheduled(t *testing.T, submit func(wp *WorkerPool, record func(string) func())) []string {
+	t.Helper()
+
+	wp, err := NewWorkerPoolWithConfig(&WorkerPoolConfig{
+		MaxWorkers: 1,
+		QueueSize:  10,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	release := make(chan struct{})
+	wp.Do(func() { <-release })
+
+	var mu sync.Mutex
+	var order []string
+	record := func(name string) func() {
+		return func() {
+			mu.Lock()
+			defer mu.Unlock()
+			order = append(order, name)
+		}
+	}
+
+	submit(wp, record)
+	close(release)
+	wp.Close()
+
+	return order
+}